@@ -0,0 +1,335 @@
+package qvalid
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/net/idna"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// AttrFunc checks attribute which can't be checked by regex, error tells why value not match.
+// args are set by tag like `attr=name:arg1|arg2`
+type AttrFunc func(value string, args []string) error
+
+// FormatPack is a set of attributes registered together by RegisterFormatPack
+type FormatPack map[string]AttrFunc
+
+var stringFuncMu sync.RWMutex
+
+var stringFuncMap = map[string]AttrFunc{
+	StringTypeIp:         withoutArgs(checkIP),
+	StringTypeIpv4:       withoutArgs(checkIPv4),
+	StringTypeIpv6:       withoutArgs(checkIPv6),
+	StringTypeCIDR:       withoutArgs(checkCIDR),
+	StringTypeCIDRv4:     withoutArgs(checkCIDRv4),
+	StringTypeCIDRv6:     withoutArgs(checkCIDRv6),
+	StringTypeMAC:        withoutArgs(checkMAC),
+	StringTypeHostPort:   withoutArgs(checkHostPort),
+	StringTypePort:       withoutArgs(checkPort),
+	StringTypeUUID3:      withoutArgs(checkUUIDVersion('3')),
+	StringTypeUUID4:      withoutArgs(checkUUIDVersion('4')),
+	StringTypeUUID5:      withoutArgs(checkUUIDVersion('5')),
+	StringTypeUUID7:      withoutArgs(checkUUIDVersion('7')),
+	StringTypeKSUID:      withoutArgs(checkKSUID),
+	StringTypeSnowflake:  withoutArgs(checkSnowflake),
+	StringTypeEmail:      checkEmail,
+	StringTypeURL:        checkURL,
+	StringTypeHttpURL:    checkHttpURL,
+	StringTypeURI:        checkURI,
+	StringTypeCreditCard: checkCreditCard,
+	StringTypeIBAN:       withoutArgs(checkIBAN),
+	StringTypeISBN10:     withoutArgs(checkISBN10),
+	StringTypeISBN13:     withoutArgs(checkISBN13),
+	StringTypeEAN13:      withoutArgs(checkEAN13),
+	StringTypeBIC:        withoutArgs(checkBIC),
+	StringTypeJSON:       withoutArgs(checkJSON),
+	StringTypeJWT:        withoutArgs(checkJWT),
+	StringTypeBase64URL:  withoutArgs(checkBase64URL),
+	StringTypeBase64Raw:  withoutArgs(checkBase64Raw),
+	StringTypeRGB:        withoutArgs(checkRGB),
+	StringTypeRFC3339:    withoutArgs(checkRFC3339),
+}
+
+// RegisterAttribute registers attribute checked by fn, it replaces the attribute with same name
+func RegisterAttribute(name string, fn AttrFunc) {
+	stringFuncMu.Lock()
+	defer stringFuncMu.Unlock()
+	stringFuncMap[name] = fn
+}
+
+// RegisterFormatPack registers all attributes of pack
+func RegisterFormatPack(pack FormatPack) {
+	for name, fn := range pack {
+		RegisterAttribute(name, fn)
+	}
+}
+
+func getAttrFunc(name string) (AttrFunc, bool) {
+	stringFuncMu.RLock()
+	defer stringFuncMu.RUnlock()
+	fn, ok := stringFuncMap[name]
+	return fn, ok
+}
+
+func withoutArgs(fn func(value string) error) AttrFunc {
+	return func(value string, args []string) error {
+		return fn(value)
+	}
+}
+
+// split `name:arg1|arg2` into name and args
+func parseAttr(attr string) (string, []string) {
+	parts := strings.SplitN(attr, ":", 2)
+	if len(parts) == 1 || parts[1] == "" {
+		return parts[0], nil
+	}
+	return parts[0], strings.Split(parts[1], "|")
+}
+
+func checkIP(value string) error {
+	if _, err := netip.ParseAddr(value); err != nil {
+		return errors.New("not an IP address")
+	}
+	return nil
+}
+
+func checkIPv4(value string) error {
+	addr, err := netip.ParseAddr(value)
+	if err != nil || !addr.Is4() {
+		return errors.New("not an IPv4 address")
+	}
+	return nil
+}
+
+func checkIPv6(value string) error {
+	addr, err := netip.ParseAddr(value)
+	if err != nil || !addr.Is6() {
+		return errors.New("not an IPv6 address")
+	}
+	return nil
+}
+
+func checkCIDR(value string) error {
+	if _, err := netip.ParsePrefix(value); err != nil {
+		return errors.New("not a CIDR notation")
+	}
+	return nil
+}
+
+func checkCIDRv4(value string) error {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil || !prefix.Addr().Is4() {
+		return errors.New("not an IPv4 CIDR notation")
+	}
+	return nil
+}
+
+func checkCIDRv6(value string) error {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil || !prefix.Addr().Is6() {
+		return errors.New("not an IPv6 CIDR notation")
+	}
+	return nil
+}
+
+func checkMAC(value string) error {
+	if _, err := net.ParseMAC(value); err != nil {
+		return errors.New("not a MAC address")
+	}
+	return nil
+}
+
+// host is IP or DNS name, port is 1-65535
+func checkHostPort(value string) error {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return errors.New("not in format host:port")
+	}
+	if checkIP(host) != nil && !stringRegexMap[StringTypeDNS].MatchString(host) {
+		return fmt.Errorf("host:%s is neither IP nor DNS name", host)
+	}
+	if checkPort(port) != nil {
+		return fmt.Errorf("port:%s not in range 1-65535", port)
+	}
+	return nil
+}
+
+// port is decimal number in 1-65535
+func checkPort(value string) error {
+	if n, err := strconv.ParseUint(value, 10, 16); err != nil || n == 0 {
+		return errors.New("not a port number in range 1-65535")
+	}
+	return nil
+}
+
+// version is the 13th hex digit, variant of RFC 4122 is 10xx
+func checkUUIDVersion(version byte) func(value string) error {
+	return func(value string) error {
+		if !stringRegexMap[StringTypeUUID].MatchString(value) {
+			return errors.New("not a UUID")
+		}
+		if value[14] != version {
+			return fmt.Errorf("UUID version is %c, not %c", value[14], version)
+		}
+		if !strings.ContainsRune("89abAB", rune(value[19])) {
+			return errors.New("UUID variant is not RFC 4122")
+		}
+		return nil
+	}
+}
+
+// max KSUID is 2^160-1 in base62
+const maxKSUID = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+
+var ksuidRegex = regexp.MustCompile(KSUID)
+
+// base62 alphabet is in ascii order, so compare as string
+func checkKSUID(value string) error {
+	if !ksuidRegex.MatchString(value) || value > maxKSUID {
+		return errors.New("not a KSUID")
+	}
+	return nil
+}
+
+// snowflake ID is positive int64 in decimal
+func checkSnowflake(value string) error {
+	if !stringRegexMap[StringTypeNumeric].MatchString(value) {
+		return errors.New("not a snowflake ID")
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err != nil || n == 0 || value[0] == '0' {
+		return errors.New("not a snowflake ID")
+	}
+	return nil
+}
+
+// options of email attribute
+const (
+	EmailOptionRFC5321 = "rfc5321" // limit length of local part, domain and address
+	EmailOptionIDN     = "idn"     // allow internationalized domain name
+)
+
+// email address without display name, domain must be a DNS name with dot
+func checkEmail(value string, args []string) error {
+	addr, err := mail.ParseAddress(value)
+	if err != nil || addr.Name != "" || strings.TrimSpace(value) != value || strings.ContainsAny(value, "<>()") {
+		return errors.New("not an email address")
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	local, domain := addr.Address[:at], addr.Address[at+1:]
+
+	for _, v := range args {
+		if v != EmailOptionRFC5321 && v != EmailOptionIDN {
+			return fmt.Errorf("email option:%s not supported", v)
+		}
+	}
+	if isInStringSlice(EmailOptionIDN, args) {
+		if domain, err = idna.Lookup.ToASCII(domain); err != nil {
+			return fmt.Errorf("domain:%s is not a valid IDN", addr.Address[at+1:])
+		}
+	}
+	if !hostnameRegex.MatchString(domain) || !strings.Contains(domain, ".") {
+		return fmt.Errorf("domain:%s is not a valid DNS name", domain)
+	}
+
+	if isInStringSlice(EmailOptionRFC5321, args) {
+		if len(local) > 64 {
+			return errors.New("local part is longer than 64 octets")
+		}
+		if len(domain) > 255 {
+			return errors.New("domain is longer than 255 octets")
+		}
+		if len(local)+1+len(domain) > 254 {
+			return errors.New("address is longer than 254 octets")
+		}
+	}
+	return nil
+}
+
+var hostnameRegex = regexp.MustCompile(Hostname)
+
+// options of url attribute, other options are allowed schemes
+const (
+	URLOptionAbsolute = "absolute" // require scheme and host, parsed by net/url
+)
+
+var (
+	urlRegex    = regexp.MustCompile(URL)
+	httpSchemes = []string{"http", "https"}
+)
+
+// url is matched by regex without options, scheme is optional, e.g. example.com.
+// with options it must be an absolute URL with host, options other than absolute are allowed schemes
+func checkURL(value string, args []string) error {
+	if len(args) == 0 {
+		if !urlRegex.MatchString(value) {
+			return errors.New("not a URL")
+		}
+		return nil
+	}
+	schemes := make([]string, 0, len(args))
+	for _, v := range args {
+		if v != URLOptionAbsolute {
+			schemes = append(schemes, v)
+		}
+	}
+	return checkAbsoluteURL(value, schemes)
+}
+
+// absolute URL with host, args are allowed schemes
+func checkAbsoluteURL(value string, args []string) error {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.New("not an absolute URL with host")
+	}
+	if checkIP(u.Hostname()) != nil {
+		host, err := idna.Lookup.ToASCII(u.Hostname())
+		if err != nil || !hostnameRegex.MatchString(host) {
+			return fmt.Errorf("host:%s is neither IP nor DNS name", u.Hostname())
+		}
+	}
+	if u.Port() != "" && checkPort(u.Port()) != nil {
+		return fmt.Errorf("port:%s not in range 1-65535", u.Port())
+	}
+	return checkScheme(u.Scheme, args)
+}
+
+// absolute URL with scheme http or https, args narrow the schemes, e.g. `http_url:https`
+func checkHttpURL(value string, args []string) error {
+	for _, v := range args {
+		if !isInStringSlice(strings.ToLower(v), httpSchemes) {
+			return fmt.Errorf("http_url option:%s not supported", v)
+		}
+	}
+	if len(args) == 0 {
+		args = httpSchemes
+	}
+	return checkAbsoluteURL(value, args)
+}
+
+// absolute URI, host is optional, args are allowed schemes
+func checkURI(value string, args []string) error {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return errors.New("not an absolute URI")
+	}
+	return checkScheme(u.Scheme, args)
+}
+
+func checkScheme(scheme string, schemes []string) error {
+	if len(schemes) == 0 {
+		return nil
+	}
+	for _, v := range schemes {
+		if strings.EqualFold(v, scheme) {
+			return nil
+		}
+	}
+	return fmt.Errorf("scheme:%s not in:%v", scheme, schemes)
+}
@@ -11,6 +11,8 @@ const (
 	LowerAlpha     string = "^[a-z]+$"
 	Alpha          string = "^[a-zA-Z]+$"
 	Alphanumeric   string = "^[a-zA-Z0-9]+$"
+	AlphaUnicode   string = "^[\\pL\\pM]+$"
+	AlnumUnicode   string = "^[\\pL\\pM\\pN]+$"
 	Numeric        string = "^[0-9]+$"
 	Int            string = "^(?:[-+]?(?:0|[1-9][0-9]*))$" // +- 0|1..
 	Float          string = "^(?:[-+]?(?:[0-9]+))?(?:\\.[0-9]*)?(?:[eE][\\+\\-]?(?:[0-9]+))?$"
@@ -19,6 +21,7 @@ const (
 	MultiByte      string = "[^\x00-\x7F]"
 	Base64         string = "^(?:[A-Za-z0-9+\\/]{4})*(?:[A-Za-z0-9+\\/]{2}==|[A-Za-z0-9+\\/]{3}=|[A-Za-z0-9+\\/]{4})$"
 	PrintableASCII string = "^[\x20-\x7E]+$"
+	Hostname       string = `^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`
 	DNSName        string = `^([a-zA-Z0-9_]{1}[a-zA-Z0-9_-]{0,62}){1}(\.[a-zA-Z0-9_]{1}[a-zA-Z0-9_-]{0,62})*[\._]?$`
 	IP             string = `(([0-9a-fA-F]{1,4}:){7,7}[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,7}:|([0-9a-fA-F]{1,4}:){1,6}:[0-9a-fA-F]{1,4}|([0-9a-fA-F]{1,4}:){1,5}(:[0-9a-fA-F]{1,4}){1,2}|([0-9a-fA-F]{1,4}:){1,4}(:[0-9a-fA-F]{1,4}){1,3}|([0-9a-fA-F]{1,4}:){1,3}(:[0-9a-fA-F]{1,4}){1,4}|([0-9a-fA-F]{1,4}:){1,2}(:[0-9a-fA-F]{1,4}){1,5}|[0-9a-fA-F]{1,4}:((:[0-9a-fA-F]{1,4}){1,6})|:((:[0-9a-fA-F]{1,4}){1,7}|:)|fe80:(:[0-9a-fA-F]{0,4}){0,4}%[0-9a-zA-Z]{1,}|::(ffff(:0{1,4}){0,1}:){0,1}((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])|([0-9a-fA-F]{1,4}:){1,4}:((25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9])\.){3,3}(25[0-5]|(2[0-4]|1{0,1}[0-9]){0,1}[0-9]))`
 	URLSchema      string = `((ftp|tcp|udp|wss?|https?):\/\/)`
@@ -28,11 +31,19 @@ const (
 	URLIP          string = `([1-9]\d?|1\d\d|2[01]\d|22[0-3])(\.(1?\d{1,2}|2[0-4]\d|25[0-5])){2}(?:\.([0-9]\d?|1\d\d|2[0-4]\d|25[0-4]))`
 	URLSubdomain   string = `((www\.)|([a-zA-Z0-9]+([-_\.]?[a-zA-Z0-9])*[a-zA-Z0-9]\.[a-zA-Z0-9]+))`
 	URL            string = `^` + URLSchema + `?` + URLUsername + `?` + `((` + URLIP + `|(\[` + IP + `\])|(([a-zA-Z0-9]([a-zA-Z0-9-_]+)?[a-zA-Z0-9]([-\.][a-zA-Z0-9]+)*)|(` + URLSubdomain + `?))?(([a-zA-Z\x{00a1}-\x{ffff}0-9]+-?-?)*[a-zA-Z\x{00a1}-\x{ffff}0-9]+)(?:\.([a-zA-Z\x{00a1}-\x{ffff}]{1,}))?))\.?` + URLPort + `?` + URLPath + `?$`
+	UUID           string = "^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$"
+	ULID           string = "^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$"
+	KSUID          string = "^[0-9A-Za-z]{27}$"
+	ObjectID       string = "^[0-9a-fA-F]{24}$"
+	HexColor       string = "^#(?:[0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$"
+	DNSLabel       string = "^[a-z0-9](?:[-a-z0-9]{0,61}[a-z0-9])?$"
 	Semver         string = "^v?(?:0|[1-9]\\d*)\\.(?:0|[1-9]\\d*)\\.(?:0|[1-9]\\d*)(-(0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(\\.(0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*)?(\\+[0-9a-zA-Z-]+(\\.[0-9a-zA-Z-]+)*)?$"
 )
 
 const (
 	validTag string = "valid"
+	// tag of constraints applied to each key of map field
+	validKeyTag string = "validkey"
 )
 
 const (
@@ -41,38 +52,81 @@ const (
 	StringTypeUpperAlpha   = "upper_alpha"
 	StringTypeLowerAlpha   = "lower_alpha"
 	StringTypeAlphaNumeric = "alpha_numeric"
+	StringTypeAlphaUnicode = "alpha_unicode"
+	StringTypeAlnumUnicode = "alphanumeric_unicode"
 	StringTypeNumeric      = "numeric"
 	StringTypeInt          = "int"
 	StringTypeFloat        = "float"
 	StringTypeHex          = "hex"
 	StringTypeAscii        = "ascii"
 	StringTypeVisibleAscii = "visible_ascii"
-	StringTypeBytes        = "bytes"
+	StringTypeMultiByte    = "multibyte" // contains multibyte character
+	StringTypeBytes        = "bytes"     // Deprecated: same as multibyte
 	StringTypeBase64       = "base64"
 	StringTypeDNS          = "dns"
 	StringTypeVersion      = "version"
 	StringTypeIp           = "ip"
+	StringTypeIpv4         = "ipv4"
+	StringTypeIpv6         = "ipv6"
+	StringTypeCIDR         = "cidr"
+	StringTypeCIDRv4       = "cidrv4"
+	StringTypeCIDRv6       = "cidrv6"
+	StringTypeMAC          = "mac"
+	StringTypeHostPort     = "hostport"
 	StringTypePort         = "port"
 	StringTypeURL          = "url"
+	StringTypeHttpURL      = "http_url"
+	StringTypeURI          = "uri"
+	StringTypeUUID         = "uuid"
+	StringTypeUUID3        = "uuid3"
+	StringTypeUUID4        = "uuid4"
+	StringTypeUUID5        = "uuid5"
+	StringTypeUUID7        = "uuid7"
+	StringTypeULID         = "ulid"
+	StringTypeKSUID        = "ksuid"
+	StringTypeObjectID     = "mongo_objectid"
+	StringTypeSnowflake    = "snowflake"
+	StringTypeE164         = "e164"
+	StringTypeMobile       = "mobile"
+	StringTypePostcode     = "postcode"
+	StringTypeCNID         = "cn_id"
+	StringTypeCreditCard   = "credit_card"
+	StringTypeIBAN         = "iban"
+	StringTypeISBN10       = "isbn10"
+	StringTypeISBN13       = "isbn13"
+	StringTypeEAN13        = "ean13"
+	StringTypeBIC          = "bic"
+	StringTypeJSON         = "json"
+	StringTypeJWT          = "jwt"
+	StringTypeBase64URL    = "base64url"
+	StringTypeBase64Raw    = "base64raw"
+	StringTypeHexColor     = "hexcolor"
+	StringTypeRGB          = "rgb"
+	StringTypeRFC3339      = "rfc3339"
+	StringTypeDNSLabel     = "dns_label" // RFC 1123 label, e.g. key of kubernetes labels
 )
 
 var stringRegexMap = map[string]*regexp.Regexp{
-	StringTypeEmail:        regexp.MustCompile(Email),
 	StringTypeAlpha:        regexp.MustCompile(Alpha),
 	StringTypeUpperAlpha:   regexp.MustCompile(UpperAlpha),
 	StringTypeLowerAlpha:   regexp.MustCompile(LowerAlpha),
 	StringTypeAlphaNumeric: regexp.MustCompile(Alphanumeric),
+	StringTypeAlphaUnicode: regexp.MustCompile(AlphaUnicode),
+	StringTypeAlnumUnicode: regexp.MustCompile(AlnumUnicode),
 	StringTypeNumeric:      regexp.MustCompile(Numeric),
 	StringTypeInt:          regexp.MustCompile(Int),
 	StringTypeFloat:        regexp.MustCompile(Float),
 	StringTypeHex:          regexp.MustCompile(Hexadecimal),
 	StringTypeAscii:        regexp.MustCompile(ASCII),
 	StringTypeVisibleAscii: regexp.MustCompile(PrintableASCII),
+	StringTypeMultiByte:    regexp.MustCompile(MultiByte),
 	StringTypeBytes:        regexp.MustCompile(MultiByte),
 	StringTypeBase64:       regexp.MustCompile(Base64),
 	StringTypeDNS:          regexp.MustCompile(DNSName),
 	StringTypeVersion:      regexp.MustCompile(Semver),
-	StringTypeIp:           regexp.MustCompile(URLIP),
-	StringTypePort:         regexp.MustCompile(URLPort),
-	StringTypeURL:          regexp.MustCompile(URL),
+	StringTypeUUID:         regexp.MustCompile(UUID),
+	StringTypeULID:         regexp.MustCompile(ULID),
+	StringTypeObjectID:     regexp.MustCompile(ObjectID),
+	StringTypeHexColor:     regexp.MustCompile(HexColor),
+	StringTypeDNSLabel:     regexp.MustCompile(DNSLabel),
 }
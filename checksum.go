@@ -0,0 +1,215 @@
+package qvalid
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// codes of AttrError, set to Code of ValidError
+const (
+	CodeInvalidFormat    = "invalid_format"
+	CodeInvalidLength    = "invalid_length"
+	CodeInvalidChecksum  = "invalid_checksum"
+	CodeUnsupportedBrand = "unsupported_brand"
+	CodeUnknownCountry   = "unknown_country"
+)
+
+// AttrError can be returned by AttrFunc to tell the reason by code
+type AttrError struct {
+	Code string
+	Msg  string
+}
+
+func (e *AttrError) Error() string {
+	return e.Msg
+}
+
+func newAttrError(code string, format string, a ...interface{}) *AttrError {
+	return &AttrError{
+		Code: code,
+		Msg:  fmt.Sprintf(format, a...),
+	}
+}
+
+// remove spaces and hyphens used for readability
+func stripSeparators(value string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(value)
+}
+
+var digitsRegex = regexp.MustCompile(`^\d+$`)
+
+// digits pass Luhn check
+func isLuhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		n := int(digits[i] - '0')
+		if double {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+type cardBrand struct {
+	name    string
+	prefix  *regexp.Regexp
+	lengths []int
+}
+
+// card brands, detected by prefix of card number
+var cardBrands = []cardBrand{
+	{"visa", regexp.MustCompile(`^4`), []int{13, 16, 19}},
+	{"mastercard", regexp.MustCompile(`^(5[1-5]|222[1-9]|22[3-9]\d|2[3-6]\d\d|27[01]\d|2720)`), []int{16}},
+	{"amex", regexp.MustCompile(`^3[47]`), []int{15}},
+	{"diners", regexp.MustCompile(`^(30[0-5]|3095|36|3[89])`), []int{14, 15, 16, 17, 18, 19}},
+	{"discover", regexp.MustCompile(`^(6011|64[4-9]|65)`), []int{16, 17, 18, 19}},
+	{"jcb", regexp.MustCompile(`^35(2[89]|[3-8]\d)`), []int{16, 17, 18, 19}},
+	{"unionpay", regexp.MustCompile(`^62`), []int{16, 17, 18, 19}},
+}
+
+// DetectCardBrand returns brand of card number, or empty string if unknown
+func DetectCardBrand(number string) string {
+	number = stripSeparators(number)
+	for _, brand := range cardBrands {
+		if brand.prefix.MatchString(number) {
+			for _, length := range brand.lengths {
+				if len(number) == length {
+					return brand.name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// card number passes Luhn check, args are allowed brands
+func checkCreditCard(value string, args []string) error {
+	number := stripSeparators(value)
+	if !digitsRegex.MatchString(number) {
+		return newAttrError(CodeInvalidFormat, "card number must be digits")
+	}
+	if len(number) < 12 || len(number) > 19 {
+		return newAttrError(CodeInvalidLength, "length of card number must be in 12-19")
+	}
+	if !isLuhnValid(number) {
+		return newAttrError(CodeInvalidChecksum, "card number fails Luhn check")
+	}
+	if len(args) > 0 {
+		brand := DetectCardBrand(number)
+		if !isInStringSlice(brand, args) {
+			return newAttrError(CodeUnsupportedBrand, "card brand:%s not in:%v", brand, args)
+		}
+	}
+	return nil
+}
+
+// length of IBAN by country
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BR": 29,
+	"BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29,
+	"ES": 24, "FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28,
+	"HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+	"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19,
+	"MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29,
+	"RO": 24, "RS": 22, "SA": 24, "SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+var ibanRegex = regexp.MustCompile(`^[A-Z]{2}\d{2}[A-Z0-9]+$`)
+
+// IBAN passes mod-97 check, spaces are allowed
+func checkIBAN(value string) error {
+	iban := strings.ToUpper(strings.Replace(value, " ", "", -1))
+	if !ibanRegex.MatchString(iban) {
+		return newAttrError(CodeInvalidFormat, "not an IBAN")
+	}
+	length, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return newAttrError(CodeUnknownCountry, "country:%s has no IBAN", iban[:2])
+	}
+	if len(iban) != length {
+		return newAttrError(CodeInvalidLength, "length of IBAN of country:%s must be %d", iban[:2], length)
+	}
+
+	// move the first 4 chars to the end, and replace letters with 10-35
+	var digits strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' {
+			digits.WriteString(fmt.Sprint(r - 'A' + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return newAttrError(CodeInvalidChecksum, "IBAN fails mod-97 check")
+	}
+	return nil
+}
+
+var isbn10Regex = regexp.MustCompile(`^\d{9}[\dX]$`)
+
+// ISBN-10, hyphens and spaces are allowed
+func checkISBN10(value string) error {
+	isbn := stripSeparators(value)
+	if !isbn10Regex.MatchString(isbn) {
+		return newAttrError(CodeInvalidFormat, "not an ISBN-10")
+	}
+	sum := 0
+	for i := 0; i < 10; i++ {
+		n := int(isbn[i] - '0')
+		if isbn[i] == 'X' {
+			n = 10
+		}
+		sum += n * (10 - i)
+	}
+	if sum%11 != 0 {
+		return newAttrError(CodeInvalidChecksum, "ISBN-10 check digit is wrong")
+	}
+	return nil
+}
+
+// ISBN-13 is EAN-13 with prefix 978 or 979
+func checkISBN13(value string) error {
+	isbn := stripSeparators(value)
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return newAttrError(CodeInvalidFormat, "ISBN-13 must start with 978 or 979")
+	}
+	return checkEAN13(isbn)
+}
+
+func checkEAN13(value string) error {
+	if len(value) != 13 || !digitsRegex.MatchString(value) {
+		return newAttrError(CodeInvalidFormat, "must be 13 digits")
+	}
+	sum := 0
+	for i := 0; i < 12; i++ {
+		n := int(value[i] - '0')
+		if i%2 == 1 {
+			n *= 3
+		}
+		sum += n
+	}
+	if (10-sum%10)%10 != int(value[12]-'0') {
+		return newAttrError(CodeInvalidChecksum, "check digit is wrong")
+	}
+	return nil
+}
+
+var bicRegex = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// BIC of 8 or 11 chars
+func checkBIC(value string) error {
+	if !bicRegex.MatchString(value) {
+		return newAttrError(CodeInvalidFormat, "not a BIC")
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package qvalid
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// value of `unique` and `sorted` without setting
+const (
+	flagTrue   = "true"
+	sortedAsc  = "asc"
+	sortedDesc = "desc"
+)
+
+// element of slice, array or map key, name is `[i]` or `[key]` in error message
+type collectionElem struct {
+	name  string
+	value reflect.Value
+}
+
+// elements of slice and array, keys of map in sorted order
+func getCollectionElems(v reflect.Value) []collectionElem {
+	elems := make([]collectionElem, 0, v.Len())
+	if v.Kind() == reflect.Map {
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			elems = append(elems, collectionElem{fmt.Sprintf("[%v]", key.Interface()), key})
+		}
+		return elems
+	}
+	for i := 0; i < v.Len(); i++ {
+		elems = append(elems, collectionElem{fmt.Sprintf("[%d]", i), v.Index(i)})
+	}
+	return elems
+}
+
+func elemNames(elems []collectionElem) string {
+	names := make([]string, 0, len(elems))
+	for _, v := range elems {
+		names = append(names, v.name)
+	}
+	return strings.Join(names, "")
+}
+
+// dereference pointer and interface, invalid value is returned for nil
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// field of struct by path like `.id` or `.owner.id`, named by json tag first
+func getFieldByPath(v reflect.Value, path string) (reflect.Value, error) {
+	for _, name := range splitPath(path) {
+		v = indirectValue(v)
+		if !v.IsValid() {
+			return v, nil
+		}
+		if v.Kind() != reflect.Struct {
+			return v, fmt.Errorf("field:%s not found in %s", name, v.Type())
+		}
+		found := false
+		for _, field := range collectFields(v) {
+			if getTagName(field.field) == name {
+				v, found = field.value, true
+				break
+			}
+		}
+		if !found {
+			return v, fmt.Errorf("field:%s not found in %s", name, v.Type())
+		}
+	}
+	return indirectValue(v), nil
+}
+
+// text of value which can't be map key, it never equals to a string element
+type textKey string
+
+// key of element to find duplicates, pointers are compared by what they point to.
+// value which panics as map key, e.g. interface field holding slice, is keyed by its text
+func uniqueKey(v reflect.Value) interface{} {
+	v = indirectValue(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if isBigNumber(v) {
+		n, _ := getNumber(v)
+		return n.String()
+	}
+	if v.CanInterface() && v.Comparable() {
+		return v.Interface()
+	}
+	return textKey(fmt.Sprintf("%#v", v))
+}
+
+// compare strings, numbers and big numbers, ok is false if they can't be compared
+func compareElems(a, b reflect.Value) (r int, ok bool) {
+	a, b = indirectValue(a), indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+	if !isNumberValue(a) || !isNumberValue(b) {
+		return 0, false
+	}
+	an, aNaN := getNumber(a)
+	bn, bNaN := getNumber(b)
+	if aNaN || bNaN {
+		return 0, false
+	}
+	return an.cmp(bn), true
+}
+
+func isNumberValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Struct:
+		return isBigNumber(v)
+	}
+	return false
+}
+
+// element equals to value in tag, number is compared by value, e.g. 1 equals to 1.0
+func elemEquals(v reflect.Value, value string) bool {
+	v = indirectValue(v)
+	if !v.IsValid() {
+		return false
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String() == value
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		return err == nil && v.Bool() == b
+	case isNumberValue(v):
+		r, ok := new(big.Rat).SetString(value)
+		n, isNaN := getNumber(v)
+		return ok && !isNaN && n.cmp(bigNumber{rat: r}) == 0
+	}
+	return v.CanInterface() && fmt.Sprint(v.Interface()) == value
+}
+
+func (c *Constraint) hasCollectionConstraint() bool {
+	return c.Unique != nil || c.Sorted != nil || c.Contains != nil || c.Excludes != nil
+}
+
+// check unique, sorted, contains and excludes of slice, array and map keys
+func (c *Constraint) checkCollection(v reflect.Value) error {
+	if !c.hasCollectionConstraint() {
+		return nil
+	}
+	elems := getCollectionElems(v)
+
+	if c.Unique != nil {
+		seen := make(map[interface{}]bool, len(elems))
+		duplicates := make([]collectionElem, 0)
+		for _, elem := range elems {
+			value := elem.value
+			if *c.Unique != flagTrue {
+				var err error
+				if value, err = getFieldByPath(value, *c.Unique); err != nil {
+					return err
+				}
+			}
+			key := uniqueKey(value)
+			if seen[key] {
+				duplicates = append(duplicates, elem)
+			}
+			seen[key] = true
+		}
+		if len(duplicates) > 0 {
+			return fmt.Errorf("duplicate elements at:%s", elemNames(duplicates))
+		}
+	}
+
+	if c.Sorted != nil {
+		if v.Kind() == reflect.Map {
+			return errors.New("sorted is not available on map")
+		}
+		for i := 1; i < len(elems); i++ {
+			r, ok := compareElems(elems[i-1].value, elems[i].value)
+			if !ok {
+				return fmt.Errorf("elements at:%s%s can't be compared", elems[i-1].name, elems[i].name)
+			}
+			if *c.Sorted == sortedDesc && r < 0 || *c.Sorted != sortedDesc && r > 0 {
+				return fmt.Errorf("element at:%s is out of order", elems[i].name)
+			}
+		}
+	}
+
+	if c.Contains != nil {
+		found := false
+		for _, elem := range elems {
+			if elemEquals(elem.value, *c.Contains) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("not contains:%s", *c.Contains)
+		}
+	}
+
+	if c.Excludes != nil {
+		found := make([]collectionElem, 0)
+		for _, elem := range elems {
+			if elemEquals(elem.value, *c.Excludes) {
+				found = append(found, elem)
+			}
+		}
+		if len(found) > 0 {
+			return fmt.Errorf("excluded:%s found at:%s", *c.Excludes, elemNames(found))
+		}
+	}
+	return nil
+}
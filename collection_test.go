@@ -0,0 +1,97 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"reflect"
+	"testing"
+)
+
+type Member struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Team struct {
+	Tags     []string          `valid:"unique, contains=go" json:"tags"`
+	Scores   [4]float64        `valid:"sorted=desc" json:"scores"`
+	Versions []int             `valid:"sorted, excludes=0" json:"versions"`
+	Members  []*Member         `valid:"unique=.id" json:"members"`
+	Roles    map[string]string `valid:"excludes=root" json:"roles"`
+}
+
+func TestCollectionConstraint(t *testing.T) {
+	Convey("TestCollectionConstraint", t, func() {
+		newTeam := func() *Team {
+			return &Team{
+				Tags:     []string{"go", "rust"},
+				Scores:   [4]float64{9.5, 9, 9, 1},
+				Versions: []int{1, 2, 2, 10},
+				Members:  []*Member{{ID: 1, Name: "tom"}, {ID: 2, Name: "tom"}},
+				Roles:    map[string]string{"admin": "tom"},
+			}
+		}
+
+		Convey("valid collections", func() {
+			isPass, validErrors := ValidateStruct(newTeam())
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("invalid collections", func() {
+			team := newTeam()
+			team.Tags = []string{"java", "c", "java", "c"}
+			team.Scores[2] = 9.8
+			team.Versions = []int{0, 3, 0}
+			team.Members = append(team.Members, &Member{ID: 1}, &Member{ID: 2})
+			team.Roles["root"] = "tom"
+			isPass, validErrors := ValidateStruct(team)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 5)
+			So(validErrors[0].Field, ShouldEqual, ".tags")
+			So(validErrors[0].Msg, ShouldEqual, "duplicate elements at:[2][3]")
+			So(validErrors[1].Msg, ShouldEqual, "element at:[2] is out of order")
+			So(validErrors[2].Msg, ShouldEqual, "element at:[2] is out of order")
+			So(validErrors[3].Msg, ShouldEqual, "duplicate elements at:[2][3]")
+			So(validErrors[4].Msg, ShouldEqual, "excluded:root found at:[root]")
+		})
+
+		Convey("contains and excludes", func() {
+			team := newTeam()
+			team.Tags = []string{"rust"}
+			team.Versions = []int{0, 1, 2}
+			isPass, validErrors := ValidateStruct(team)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Msg, ShouldEqual, "not contains:go")
+			So(validErrors[1].Msg, ShouldEqual, "excluded:0 found at:[0]")
+		})
+
+		Convey("excludes number by value", func() {
+			c, err := GetConstraintFromTag(`excludes=1.0`)
+			So(err, ShouldBeNil)
+			So(c.checkCollection(reflect.ValueOf([]uint{2, 1})).Error(), ShouldEqual, "excluded:1.0 found at:[1]")
+		})
+
+		Convey("unique of struct holding slice in interface field", func() {
+			type Item struct {
+				X interface{}
+			}
+			c, err := GetConstraintFromTag(`unique`)
+			So(err, ShouldBeNil)
+			So(c.checkCollection(reflect.ValueOf([]Item{{[]int{1}}, {[]int{2}}})), ShouldBeNil)
+			So(c.checkCollection(reflect.ValueOf([]Item{{[]int{1}}, {1}, {[]int{1}}})).Error(), ShouldEqual, "duplicate elements at:[2]")
+			So(c.checkCollection(reflect.ValueOf([]interface{}{"[]int{1}", []int{1}})), ShouldBeNil)
+		})
+
+		Convey("illegal settings", func() {
+			_, err := GetConstraintFromTag(`unique=id`)
+			So(err, ShouldNotBeNil)
+			_, err = GetConstraintFromTag(`sorted=up`)
+			So(err, ShouldNotBeNil)
+
+			c, err := GetConstraintFromTag(`unique=.code`)
+			So(err, ShouldBeNil)
+			So(c.checkCollection(reflect.ValueOf([]Member{{}})), ShouldNotBeNil)
+		})
+	})
+}
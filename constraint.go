@@ -1,11 +1,20 @@
 package qvalid
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 	"gopkg.in/yaml.v2"
+	"math"
+	"math/big"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 // check bound
@@ -142,7 +151,14 @@ func (c *Constraint) getUpperBoundLimit() float64 {
 func (c *Constraint) checkValue(path string, v reflect.Value, t reflect.StructField) (bool, *ValidError) {
 	switch v.Kind() {
 	case reflect.String, reflect.Array, reflect.Map, reflect.Slice:
-		_, err := c.checkBoundLimit(float64(v.Len()), true)
+		length := v.Len()
+		if v.Kind() == reflect.String {
+			length = stringLength(v.String(), c.getUnit())
+		}
+		_, err := c.checkBoundLimit(float64(length), true)
+		if err == nil && v.Kind() != reflect.String {
+			err = c.checkCollection(v)
+		}
 		if err != nil {
 			return false, &ValidError{
 				Field: path + getTagName(t),
@@ -154,8 +170,20 @@ func (c *Constraint) checkValue(path string, v reflect.Value, t reflect.StructFi
 			value := fmt.Sprintf("%v", v)
 			// check attribute
 			if c.Attr != nil {
-				if regex, ok := stringRegexMap[*c.Attr]; ok {
-					value := fmt.Sprintf("%v", v)
+				attr, args := parseAttr(*c.Attr)
+				if fn, ok := getAttrFunc(attr); ok {
+					if err := fn(value, args); err != nil {
+						validErr := &ValidError{
+							Field: path + getTagName(t),
+							Msg:   fmt.Sprintf("value:%s not match attribute:%s, %v", value, *c.Attr, err),
+						}
+						var attrErr *AttrError
+						if errors.As(err, &attrErr) {
+							validErr.Code = attrErr.Code
+						}
+						return false, validErr
+					}
+				} else if regex, ok := stringRegexMap[attr]; ok {
 					isMatch := regex.Match([]byte(value))
 					if !isMatch {
 						return false, &ValidError{
@@ -166,11 +194,28 @@ func (c *Constraint) checkValue(path string, v reflect.Value, t reflect.StructFi
 				}
 			}
 
-			if len(c.In) > 0 {
-				if !isInStringSlice(value, c.In) {
+			if err := c.checkSemver(value); err != nil {
+				return false, &ValidError{
+					Field: path + getTagName(t),
+					Msg:   fmt.Sprintf("value:%s %v", value, err),
+				}
+			}
+
+			if c.Datetime != nil {
+				layout := getDatetimeLayout(*c.Datetime)
+				if _, err := time.Parse(layout, value); err != nil {
+					return false, &ValidError{
+						Field: path + getTagName(t),
+						Msg:   fmt.Sprintf("value:%s not match datetime layout:%s", value, layout),
+					}
+				}
+			}
+
+			if c.hasIn() {
+				if !c.isIn(value) {
 					return false, &ValidError{
 						Field: path + getTagName(t),
-						Msg:   fmt.Sprintf("value:%s not in:%v", value, c.In),
+						Msg:   fmt.Sprintf("value:%s not in:%s", value, c.inDesc()),
 					}
 				}
 			}
@@ -180,6 +225,9 @@ func (c *Constraint) checkValue(path string, v reflect.Value, t reflect.StructFi
 		return true, nil // ignore bool check
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		_, err := c.checkBoundLimit(float64(v.Int()), false)
+		if err == nil {
+			err = c.checkNumber(v)
+		}
 		if err != nil {
 			return false, &ValidError{
 				Field: path + getTagName(t),
@@ -187,17 +235,20 @@ func (c *Constraint) checkValue(path string, v reflect.Value, t reflect.StructFi
 			}
 		}
 		value := fmt.Sprintf("%v", v)
-		if len(c.In) > 0 {
-			if !isInStringSlice(value, c.In) {
+		if c.hasIn() {
+			if !c.isIn(value) {
 				return false, &ValidError{
 					Field: path + getTagName(t),
-					Msg:   fmt.Sprintf("value:%s not in:%v", value, c.In),
+					Msg:   fmt.Sprintf("value:%s not in:%s", value, c.inDesc()),
 				}
 			}
 		}
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		_, err := c.checkBoundLimit(float64(v.Uint()), false)
+		if err == nil {
+			err = c.checkNumber(v)
+		}
 		if err != nil {
 			return false, &ValidError{
 				Field: path + getTagName(t),
@@ -205,13 +256,26 @@ func (c *Constraint) checkValue(path string, v reflect.Value, t reflect.StructFi
 			}
 		}
 	case reflect.Float32, reflect.Float64:
-		_, err := c.checkBoundLimit(float64(v.Float()), false)
+		var err error
+		if math.IsNaN(v.Float()) && c.hasBoundLimit() {
+			// every comparison with NaN is false
+			err = errors.New("value:NaN is not comparable with bound")
+		} else {
+			_, err = c.checkBoundLimit(float64(v.Float()), false)
+		}
+		if err == nil {
+			err = c.checkNumber(v)
+		}
 		if err != nil {
 			return false, &ValidError{
 				Field: path + getTagName(t),
 				Msg:   err.Error(),
 			}
 		}
+	case reflect.Struct:
+		if isBigNumber(v) {
+			return c.checkBigNumber(path, v, t)
+		}
 	case reflect.Interface, reflect.Ptr:
 		return true, nil // ignore interface
 	}
@@ -227,54 +291,253 @@ type Constraint struct {
 	Gte    *float64 `yaml:"gte"`
 	Equal  *float64 `yaml:"eq"`
 	In     []string `yaml:"in"`
+	InRef  *string  `yaml:"in_ref"` // name of enum set by `in=@name`
+	InMode *string  `yaml:"in_mode"`
 	Prefix *string  `yaml:"prefix"`
 	Suffix *string  `yaml:"suffix"`
 	//Regex  *string  `yaml:"regex"`
 	Attr *string `yaml:"attr"`
+	Func *string `yaml:"func"`
+	Type *string `yaml:"type"`
+	Unit *string `yaml:"unit"`
+	// value must be one of its type's constants, see EnumValidator
+	Enum bool `yaml:"enum"`
+	// layout of time.Parse, or name of layout in package time, e.g. RFC1123
+	Datetime *string `yaml:"datetime"`
+	// bounds of semantic version
+	SemverLt    *string `yaml:"semver_lt"`
+	SemverLte   *string `yaml:"semver_lte"`
+	SemverGt    *string `yaml:"semver_gt"`
+	SemverGte   *string `yaml:"semver_gte"`
+	SemverRange *string `yaml:"semver_range"`
+	// number must be multiple of it, e.g. `multiple_of=0.01`
+	MultipleOf *string `yaml:"multiple_of"`
+	// max count of decimal places of number
+	MaxDecimals *int `yaml:"max_decimals"`
+	// sign of number, float must not be NaN or Inf by `finite`
+	Finite   bool `yaml:"finite"`
+	Positive bool `yaml:"positive"`
+	Negative bool `yaml:"negative"`
+	NonZero  bool `yaml:"nonzero"`
+	// elements of slice, array or keys of map must be unique, or unique by field, e.g. `unique=.id`
+	Unique *string `yaml:"unique"`
+	// elements must be in ascending order, or `sorted=desc`
+	Sorted *string `yaml:"sorted"`
+	// element must be or must not be found
+	Contains *string `yaml:"contains"`
+	Excludes *string `yaml:"excludes"`
+	// text of value filled into zero field by SetDefaults, e.g. `default=8080`, `default=[a,b]`
+	Default *string `yaml:"default"`
+	// modifiers of string, string elements and map values applied before checks, field is changed in place when it can be set
+	Trim  bool `yaml:"trim"`
+	Lower bool `yaml:"lower"`
+	Upper bool `yaml:"upper"`
+	NFC   bool `yaml:"nfc"`
+
+	inSet map[string]struct{} // normalized items of In
+	// exact limits of bound in tag, keyed by lt, lte, gt, gte
+	bigLimits   map[string]*big.Rat
+	multipleOf  *big.Rat    // parsed MultipleOf
+	semverRange semverRange // parsed semver bounds and SemverRange, nil if none is set
+	hasDefault  bool        // default is set, Default is nil without value
 }
 
-var bracketHolder = `bholder1xx`
-var yamlTag = `: `
+// units of string length
+const (
+	UnitBytes     = "bytes"
+	UnitRunes     = "runes"     // default
+	UnitGraphemes = "graphemes" // user-perceived characters
+	UnitWidth     = "width"     // display width, east asian wide character is 2
+)
 
-// get constraint from tag
-func GetConstraintFromTag(tag string) (*Constraint, error) {
-	c := Constraint{}
+func (c *Constraint) getUnit() string {
+	if c.Unit == nil {
+		return UnitRunes
+	}
+	return *c.Unit
+}
 
-	transformData := tag
-	squareBracketContent := ""
-	// get square bracket content of 'in'
-	tmpStrs1 := strings.Split(tag, "[")
-	tmpStrs2 := strings.Split(tag, "]")
+func stringLength(s string, unit string) int {
+	switch unit {
+	case UnitBytes:
+		return len(s)
+	case UnitGraphemes:
+		return uniseg.GraphemeClusterCount(s)
+	case UnitWidth:
+		return uniseg.StringWidth(s)
+	default:
+		return utf8.RuneCountInString(s)
+	}
+}
 
-	if len(tmpStrs1) != len(tmpStrs2) {
-		return nil, errors.New("bracket setting error")
+// types of `type` constraint, used to assert the dynamic value of interface field
+var typeKindMap = map[string][]reflect.Kind{
+	"string": {reflect.String},
+	"bool":   {reflect.Bool},
+	"int": {reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64},
+	"float": {reflect.Float32, reflect.Float64},
+	"number": {reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64},
+	"struct": {reflect.Struct},
+	"slice":  {reflect.Slice, reflect.Array},
+	"map":    {reflect.Map},
+}
 
+// check type of value by `type=string|number`, pointer is checked by its element
+func (c *Constraint) checkType(path string, v reflect.Value, t reflect.StructField) *ValidError {
+	if c.Type == nil {
+		return nil
 	}
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	for _, name := range strings.Split(*c.Type, "|") {
+		kinds, ok := typeKindMap[strings.TrimSpace(name)]
+		if !ok {
+			return &ValidError{
+				Field: path + getTagName(t),
+				Msg:   fmt.Sprintf("type:%s not supported", name),
+			}
+		}
+		for _, kind := range kinds {
+			if v.Kind() == kind {
+				return nil
+			}
+		}
+	}
+	return &ValidError{
+		Field: path + getTagName(t),
+		Msg:   fmt.Sprintf("expect type:%s but get type:%s", *c.Type, v.Kind()),
+	}
+}
 
-	if len(tmpStrs1) > 1 && len(tmpStrs2) > 1 {
-		if len(tmpStrs1) > 2 {
-			return nil, errors.New("bracket setting error, check repeat")
+// ValidatorFunc is a customized field validator, set it to a field by tag `func=name`
+type ValidatorFunc func(ctx context.Context, v reflect.Value) error
+
+var (
+	validatorFuncMu  sync.RWMutex
+	validatorFuncMap = map[string]ValidatorFunc{}
+)
+
+// RegisterValidator registers a customized field validator with name
+func RegisterValidator(name string, fn ValidatorFunc) {
+	validatorFuncMu.Lock()
+	defer validatorFuncMu.Unlock()
+	validatorFuncMap[name] = fn
+}
+
+func getValidatorFunc(name string) (ValidatorFunc, bool) {
+	validatorFuncMu.RLock()
+	defer validatorFuncMu.RUnlock()
+	fn, ok := validatorFuncMap[name]
+	return fn, ok
+}
+
+// check by customized validator
+func (c *Constraint) checkFunc(ctx context.Context, path string, v reflect.Value, t reflect.StructField) (bool, *ValidError) {
+	if c.Func == nil {
+		return true, nil
+	}
+	fn, ok := getValidatorFunc(*c.Func)
+	if !ok {
+		return false, &ValidError{
+			Field: path + getTagName(t),
+			Msg:   fmt.Sprintf("validator func:%s not registered", *c.Func),
+		}
+	}
+	if err := fn(ctx, v); err != nil {
+		return false, &ValidError{
+			Field: path + getTagName(t),
+			Msg:   err.Error(),
 		}
+	}
+	return true, nil
+}
+
+var yamlTag = `: `
+
+// keys of Constraint in tag
+var constraintKeys = getConstraintKeys()
 
-		// join 2 part
-		transformData = tmpStrs1[0] + bracketHolder + tmpStrs2[1]
-		squareBracketContent = tag[len(tmpStrs1[0]) : len(tmpStrs2[0])+1]
+func getConstraintKeys() map[string]bool {
+	t := reflect.TypeOf(Constraint{})
+	keys := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]; key != "" {
+			keys[key] = true
+		}
 	}
+	return keys
+}
+
+// get constraint from tag
+func GetConstraintFromTag(tag string) (*Constraint, error) {
+	c := Constraint{}
 
-	transformDatas := strings.Split(transformData, ",")
+	items, err := splitTag(tag, ',')
+	if err != nil {
+		return nil, err
+	}
 
 	// change to yaml format
 	trimTransformData := ""
-	for _, v := range transformDatas {
-		trimTransformData += strings.Trim(v, " ") + "\n"
+	c.bigLimits = make(map[string]*big.Rat)
+	for _, v := range items {
+		item := strings.Trim(v, " ")
+		if item == "" {
+			continue
+		}
+		// only the first '=' splits key and value, value may contain '=' like `semver_range='>=1.2'`
+		kv := strings.SplitN(item, "=", 2)
+		// misspelled keyword is rejected rather than ignored
+		if key := strings.Trim(kv[0], " "); !constraintKeys[key] {
+			return nil, fmt.Errorf("keyword:%s not supported", key)
+		}
+		if len(kv) == 1 {
+			// keyword without value is a flag
+			if kv[0] == defaultKey {
+				// `default` without value only allocates nil pointer
+				c.hasDefault = true
+				continue
+			}
+			kv = append(kv, "true")
+		}
+		key, value := strings.Trim(kv[0], " "), strings.Trim(kv[1], " ")
+		if key == defaultKey {
+			// keep the text, it is decoded by the type of field
+			c.Default, c.hasDefault = &value, true
+			continue
+		}
+		if key == "in" && strings.HasPrefix(value, enumRefPrefix) {
+			key, value = "in_ref", strings.TrimPrefix(value, enumRefPrefix)
+		}
+		if isInStringSlice(key, limitKeys) {
+			// keep the exact limit for big numbers, limit out of range of float64 is infinite
+			if limit, ok := new(big.Rat).SetString(value); ok {
+				c.bigLimits[key] = limit
+				if f, _ := limit.Float64(); math.IsInf(f, 1) {
+					value = ".inf"
+				} else if math.IsInf(f, -1) {
+					value = "-.inf"
+				}
+			}
+		}
+		trimTransformData += key + yamlTag + value + "\n"
 	}
-	trimTransformData = strings.Replace(trimTransformData, "=", yamlTag, -1)
-
-	// fill back
-	trimTransformData = strings.Replace(trimTransformData, bracketHolder, squareBracketContent, 1)
 
 	// deserialize
-	err := yaml.Unmarshal([]byte(trimTransformData), &c)
+	if err = yaml.Unmarshal([]byte(trimTransformData), &c); err != nil {
+		return nil, err
+	}
+
+	// values of named enum are looked up when checking, so it can be registered again
+	if c.InRef != nil {
+		if _, ok := getEnum(*c.InRef); !ok {
+			return nil, fmt.Errorf("enum:%s%s not registered", enumRefPrefix, *c.InRef)
+		}
+	}
 
 	if c.Lte != nil && c.Lt != nil {
 		return nil, errors.New("lt and lte can't both set")
@@ -284,18 +547,187 @@ func GetConstraintFromTag(tag string) (*Constraint, error) {
 	}
 
 	if c.hasLowBoundLimit() && c.hasUpperBoundLimit() {
-		if c.getLowBoundLimit() >= c.getUpperBoundLimit() {
+		low, lowOK := c.getBigLowBoundLimit()
+		upper, upperOK := c.getBigUpperBoundLimit()
+		if lowOK && upperOK && low.cmp(upper) >= 0 {
 			return nil, errors.New("upper and lower bound limit illegal")
 		}
 	}
 
-	if c.hasBoundLimit() && len(c.In) > 1 {
+	if c.hasBoundLimit() && (len(c.In) > 1 || c.InRef != nil) {
 		return nil, errors.New("bound limit and 'in' can't both set")
 	}
 
+	if c.semverRange, err = c.getSemverRange(); err != nil {
+		return nil, err
+	}
+
+	if c.Unit != nil && !isInStringSlice(*c.Unit, []string{UnitBytes, UnitRunes, UnitGraphemes, UnitWidth}) {
+		return nil, fmt.Errorf("unit:%s not supported", *c.Unit)
+	}
+
+	if c.InMode != nil {
+		for _, v := range strings.Split(*c.InMode, "|") {
+			if !isInStringSlice(v, []string{InModeFold, InModeNFC, InModeTrim}) {
+				return nil, fmt.Errorf("in_mode:%s not supported", v)
+			}
+		}
+	}
+	if c.MultipleOf != nil {
+		multipleOf, ok := new(big.Rat).SetString(*c.MultipleOf)
+		if !ok || multipleOf.Sign() <= 0 {
+			return nil, fmt.Errorf("multiple_of:%s must be a positive number", *c.MultipleOf)
+		}
+		c.multipleOf = multipleOf
+	}
+	if c.MaxDecimals != nil && *c.MaxDecimals < 0 {
+		return nil, errors.New("max_decimals can't be negative")
+	}
+	if c.Positive && c.Negative {
+		return nil, errors.New("positive and negative can't both set")
+	}
+	if c.Lower && c.Upper {
+		return nil, errors.New("lower and upper can't both set")
+	}
+	if c.Unique != nil && *c.Unique != flagTrue && !strings.HasPrefix(*c.Unique, ".") {
+		return nil, fmt.Errorf("unique:%s must be a field path like .id", *c.Unique)
+	}
+	if c.Sorted != nil && !isInStringSlice(*c.Sorted, []string{flagTrue, sortedAsc, sortedDesc}) {
+		return nil, fmt.Errorf("sorted:%s not supported", *c.Sorted)
+	}
+
+	c.inSet = make(map[string]struct{}, len(c.In))
+	for _, v := range c.In {
+		c.inSet[c.normalizeIn(v)] = struct{}{}
+	}
+
 	return &c, err
 }
 
+// split s by sep, sep in brackets or quotes is ignored.
+// quote is recognized at the beginning of value. in single quotes, two single quotes mean one,
+// in double quotes, backslash escapes the next character
+func splitTag(s string, sep byte) ([]string, error) {
+	items := make([]string, 0)
+	depth := 0
+	quote := byte(0)
+	isValueStart := true
+	start := 0
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if quote != 0 {
+			switch {
+			case quote == '"' && ch == '\\':
+				i++
+			case ch == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+				i++
+			case ch == quote:
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case (ch == '\'' || ch == '"') && isValueStart:
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("bracket setting error")
+			}
+		case ch == sep && depth == 0:
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+		isValueStart = ch == ' ' && isValueStart || ch == '=' || ch == '[' || ch == ',' || ch == sep || ch == ':'
+	}
+	if quote != 0 {
+		return nil, errors.New("quote setting error")
+	}
+	if depth != 0 {
+		return nil, errors.New("bracket setting error")
+	}
+	return append(items, s[start:]), nil
+}
+
+func (c *Constraint) inDesc() string {
+	if c.InRef != nil {
+		return enumRefPrefix + *c.InRef
+	}
+	return fmt.Sprintf("%v", c.In)
+}
+
+var constraintCache sync.Map
+
+// get constraint from cache, tag is parsed only once
+func getConstraint(tag string) (*Constraint, error) {
+	if c, ok := constraintCache.Load(tag); ok {
+		return c.(*Constraint), nil
+	}
+	c, err := GetConstraintFromTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	constraintCache.Store(tag, c)
+	return c, nil
+}
+
+// modes of `in` comparison, set by `in_mode=fold|nfc|trim`
+const (
+	InModeFold = "fold" // unicode case folding
+	InModeNFC  = "nfc"  // unicode NFC normalization
+	InModeTrim = "trim" // trim leading and trailing white space
+)
+
+func (c *Constraint) normalizeIn(s string) string {
+	if c.InMode == nil {
+		return s
+	}
+	modes := strings.Split(*c.InMode, "|")
+	if isInStringSlice(InModeTrim, modes) {
+		s = strings.TrimSpace(s)
+	}
+	if isInStringSlice(InModeNFC, modes) {
+		s = norm.NFC.String(s)
+	}
+	if isInStringSlice(InModeFold, modes) {
+		s = cases.Fold().String(s)
+	}
+	return s
+}
+
+func (c *Constraint) hasIn() bool {
+	return len(c.In) > 0 || c.InRef != nil
+}
+
+// items of `in`, or current values of named enum
+func (c *Constraint) inValues() []string {
+	if c.InRef != nil {
+		values, _ := getEnum(*c.InRef)
+		return values
+	}
+	return c.In
+}
+
+func (c *Constraint) isIn(s string) bool {
+	if c.InRef != nil {
+		s = c.normalizeIn(s)
+		for _, v := range c.inValues() {
+			if c.normalizeIn(v) == s {
+				return true
+			}
+		}
+		return false
+	}
+	if c.inSet == nil {
+		return isInStringSlice(s, c.In)
+	}
+	_, ok := c.inSet[c.normalizeIn(s)]
+	return ok
+}
+
 func isInStringSlice(s string, data []string) bool {
 	for _, v := range data {
 		if v == s {
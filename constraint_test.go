@@ -1,6 +1,7 @@
 package qvalid
 
 import (
+	"context"
 	. "github.com/smartystreets/goconvey/convey"
 	"reflect"
 	"testing"
@@ -86,6 +87,126 @@ func TestGetConstraintFromTag(t *testing.T) {
 				So(isPass, ShouldEqual, true)
 			})
 		})
+		Convey("test string length unit", func() {
+			field := reflect.StructField{Name: "Name"}
+			name := reflect.ValueOf("王小明")
+
+			c, err := GetConstraintFromTag(`lt=5`)
+			So(err, ShouldBeNil)
+			isPass, validErr := c.checkValue(".", name, field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldEqual, true)
+
+			c, err = GetConstraintFromTag(`lt=5, unit=bytes`)
+			So(err, ShouldBeNil)
+			isPass, validErr = c.checkValue(".", name, field)
+			So(validErr, ShouldNotBeNil)
+			So(isPass, ShouldEqual, false)
+
+			c, err = GetConstraintFromTag(`lt=6, unit=width`)
+			So(err, ShouldBeNil)
+			isPass, validErr = c.checkValue(".", name, field)
+			So(validErr, ShouldNotBeNil)
+			So(isPass, ShouldEqual, false)
+
+			So(stringLength("e\u0301🇨🇳", UnitRunes), ShouldEqual, 4)
+			So(stringLength("e\u0301🇨🇳", UnitGraphemes), ShouldEqual, 2)
+
+			_, err = GetConstraintFromTag(`lt=5, unit=chars`)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("test datetime", func() {
+			field := reflect.StructField{Name: "Time"}
+
+			c, err := GetConstraintFromTag(`datetime=2006-01-02 15:04:05`)
+			So(err, ShouldBeNil)
+			isPass, validErr := c.checkValue(".", reflect.ValueOf("2018-12-22 10:00:00"), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldEqual, true)
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("2018-12-22T10:00:00"), field)
+			So(validErr, ShouldNotBeNil)
+			So(isPass, ShouldEqual, false)
+
+			c, err = GetConstraintFromTag(`datetime=RFC1123`)
+			So(err, ShouldBeNil)
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("Sat, 22 Dec 2018 10:00:00 UTC"), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldEqual, true)
+		})
+
+		Convey("test in mode", func() {
+			field := reflect.StructField{Name: "Name"}
+
+			c, err := GetConstraintFromTag(`in=[rose,tulip]`)
+			So(err, ShouldBeNil)
+			isPass, validErr := c.checkValue(".", reflect.ValueOf("Rose"), field)
+			So(validErr, ShouldNotBeNil)
+			So(isPass, ShouldEqual, false)
+
+			c, err = GetConstraintFromTag(`in=[rose,tulip,café], in_mode=fold|nfc|trim`)
+			So(err, ShouldBeNil)
+			for _, v := range []string{"Rose", " TULIP ", "CAFE\u0301"} {
+				isPass, validErr = c.checkValue(".", reflect.ValueOf(v), field)
+				So(validErr, ShouldBeNil)
+				So(isPass, ShouldEqual, true)
+			}
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("lily"), field)
+			So(validErr.Msg, ShouldEqual, "value:lily not in:[rose tulip café]")
+
+			_, err = GetConstraintFromTag(`in=[rose], in_mode=lower`)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("test quoted in items", func() {
+			field := reflect.StructField{Name: "Name"}
+
+			c, err := GetConstraintFromTag(`in=['a,b', 'en-US', 'x]', 'it''s', "say \"hi\""], in_mode=trim`)
+			So(err, ShouldBeNil)
+			So(c.In, ShouldResemble, []string{"a,b", "en-US", "x]", "it's", `say "hi"`})
+			So(*c.InMode, ShouldEqual, InModeTrim)
+			for _, v := range c.In {
+				isPass, validErr := c.checkValue(".", reflect.ValueOf(v), field)
+				So(validErr, ShouldBeNil)
+				So(isPass, ShouldEqual, true)
+			}
+
+			_, err = GetConstraintFromTag(`in=['a,b]`)
+			So(err, ShouldNotBeNil)
+			_, err = GetConstraintFromTag(`in=[a,b`)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("test named enum", func() {
+			field := reflect.StructField{Name: "Currency"}
+
+			_, err := GetConstraintFromTag(`in=@test_currencies`)
+			So(err, ShouldNotBeNil)
+
+			RegisterEnum("test_currencies", "USD", "EUR")
+			c, err := GetConstraintFromTag(`in=@test_currencies, in_mode=fold`)
+			So(err, ShouldBeNil)
+			isPass, validErr := c.checkValue(".", reflect.ValueOf("usd"), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldEqual, true)
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("JPY"), field)
+			So(isPass, ShouldEqual, false)
+			So(validErr.Msg, ShouldEqual, "value:JPY not in:@test_currencies")
+
+			// registered again, parsed and cached constraints see the new values
+			constraints, err := getActiveConstraints(context.Background(), `in=@test_currencies`)
+			So(err, ShouldBeNil)
+			RegisterEnum("test_currencies", "JPY")
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("jpy"), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldEqual, true)
+			isPass, validErr = constraints[0].checkValue(".", reflect.ValueOf("JPY"), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldEqual, true)
+			isPass, _ = constraints[0].checkValue(".", reflect.ValueOf("USD"), field)
+			So(isPass, ShouldEqual, false)
+		})
+
 		Convey("test constraint string", func() {
 
 			var str string
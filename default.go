@@ -0,0 +1,119 @@
+package qvalid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"reflect"
+)
+
+// key of default value in tag
+const defaultKey = "default"
+
+// SetDefaults fills zero fields of struct by tag `default=`, s must be a pointer to struct.
+// fields of nested structs and struct elements of slices and arrays are filled as well,
+// nil pointer is allocated when it has `default`, e.g. `valid:"default=8080"` on *int,
+// `valid:"default"` without value only allocates it, fields of allocated struct are filled
+func SetDefaults(s interface{}) error {
+	val := reflect.ValueOf(s)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return errors.New("input must be pointer to struct")
+	}
+	return setStructDefaults("", val.Elem())
+}
+
+// DefaultAndValidate fills defaults by SetDefaults then validates s
+func DefaultAndValidate(s interface{}) (bool, []*ValidError) {
+	if err := SetDefaults(s); err != nil {
+		return false, []*ValidError{{
+			Field: systemTips + " SetDefaults",
+			Msg:   err.Error(),
+		}}
+	}
+	return ValidateStruct(s)
+}
+
+func setStructDefaults(path string, val reflect.Value) error {
+	newPath := path + "."
+	for _, field := range collectFields(val) {
+		tag := field.field.Tag.Get(validTag)
+		if tag == "-" {
+			continue
+		}
+		defaultValue, hasDefault, err := getDefault(tag)
+		if err != nil {
+			return err
+		}
+		if err := setDefault(newPath+getTagName(field.field), field.value, defaultValue, hasDefault); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// default value of tag in default group
+func getDefault(tag string) (*string, bool, error) {
+	constraints, err := getActiveConstraints(context.Background(), tag)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, c := range constraints {
+		if c.hasDefault {
+			return c.Default, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func setDefault(path string, v reflect.Value, defaultValue *string, hasDefault bool) error {
+	if !v.CanSet() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			if !hasDefault {
+				return nil
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setDefault(path, v.Elem(), defaultValue, hasDefault)
+	case reflect.Struct:
+		if isValueStruct(v) {
+			break
+		}
+		return setStructDefaults(path, v)
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 || v.Kind() == reflect.Array && defaultValue != nil && v.IsZero() {
+			break
+		}
+		// fill struct elements
+		for i := 0; i < v.Len(); i++ {
+			if err := setDefault(path+fmt.Sprintf("[%d]", i), v.Index(i), nil, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !hasDefault || defaultValue == nil || !v.IsZero() {
+		return nil
+	}
+	// decode default value into the type of field, e.g. `default=[a,b]` into []string,
+	// string is taken as it is unless quoted, e.g. `default=yes` is not turned into true
+	p := reflect.New(v.Type())
+	text := *defaultValue
+	if v.Kind() == reflect.String && !isQuoted(text) {
+		p.Elem().SetString(text)
+	} else if err := yaml.Unmarshal([]byte(text), p.Interface()); err != nil {
+		return fmt.Errorf("default:%s can't be set to field:%s of type:%s", text, path, v.Type())
+	}
+	v.Set(p.Elem())
+	return nil
+}
+
+// value quoted by single or double quotes in tag
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]
+}
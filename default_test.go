@@ -0,0 +1,97 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+	"time"
+)
+
+type ServerConfig struct {
+	Host    string        `valid:"default=localhost" json:"host"`
+	Port    *int          `valid:"default=8080, gt=0, lt=65536" json:"port"`
+	Timeout time.Duration `valid:"default=30s" json:"timeout"`
+	Tags    []string      `valid:"default=[web, 'a,b']" json:"tags"`
+	Debug   bool          `valid:"default=true" json:"debug"`
+	TLS     *TLSConfig    `valid:"default" json:"tls"`
+	Backup  *TLSConfig    `json:"backup"`
+	Peers   []Peer        `json:"peers"`
+	Mirrors [2]Peer       `json:"mirrors"`
+	Ports   [2]int        `valid:"default=[80, 443]" json:"ports"`
+	Retries *int          `valid:"default" json:"retries"`
+}
+
+type TLSConfig struct {
+	Version string `valid:"default='1.3', in=['1.2', '1.3']" json:"version"`
+}
+
+type Peer struct {
+	Weight int `valid:"default=1, gte=1" json:"weight"`
+}
+
+func TestSetDefaults(t *testing.T) {
+	Convey("TestSetDefaults", t, func() {
+		Convey("fill zero fields", func() {
+			config := &ServerConfig{Peers: []Peer{{}, {Weight: 3}}}
+			So(SetDefaults(config), ShouldBeNil)
+			So(config.Host, ShouldEqual, "localhost")
+			So(*config.Port, ShouldEqual, 8080)
+			So(config.Timeout, ShouldEqual, 30*time.Second)
+			So(config.Tags, ShouldResemble, []string{"web", "a,b"})
+			So(config.Debug, ShouldBeTrue)
+			So(config.TLS.Version, ShouldEqual, "1.3")
+			So(config.Backup, ShouldBeNil)
+			So(config.Peers[0].Weight, ShouldEqual, 1)
+			So(config.Peers[1].Weight, ShouldEqual, 3)
+			So(config.Mirrors[0].Weight, ShouldEqual, 1)
+			So(config.Mirrors[1].Weight, ShouldEqual, 1)
+			So(config.Ports, ShouldResemble, [2]int{80, 443})
+			So(config.Retries, ShouldNotBeNil)
+			So(*config.Retries, ShouldEqual, 0)
+		})
+
+		Convey("keep non-zero fields", func() {
+			port := 80
+			config := &ServerConfig{Host: "example.com", Port: &port, Tags: []string{"api"}, TLS: &TLSConfig{Version: "1.2"}}
+			So(SetDefaults(config), ShouldBeNil)
+			So(config.Host, ShouldEqual, "example.com")
+			So(*config.Port, ShouldEqual, 80)
+			So(config.Tags, ShouldResemble, []string{"api"})
+			So(config.TLS.Version, ShouldEqual, "1.2")
+		})
+
+		Convey("default and validate", func() {
+			isPass, validErrors := DefaultAndValidate(&ServerConfig{Backup: &TLSConfig{Version: "1.0"}})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".backup.version")
+		})
+
+		Convey("string is taken as it is", func() {
+			type Switch struct {
+				State   string   `valid:"default=yes"`
+				Mode    string   `valid:"default=off"`
+				Code    string   `valid:"default=0123"`
+				Version string   `valid:"default=1.10"`
+				Quoted  string   `valid:"default='it''s'"`
+				Names   []string `valid:"default=[yes, 0123]"`
+			}
+			s := &Switch{}
+			So(SetDefaults(s), ShouldBeNil)
+			So(s.State, ShouldEqual, "yes")
+			So(s.Mode, ShouldEqual, "off")
+			So(s.Code, ShouldEqual, "0123")
+			So(s.Version, ShouldEqual, "1.10")
+			So(s.Quoted, ShouldEqual, "it's")
+			So(s.Names, ShouldResemble, []string{"yes", "0123"})
+		})
+
+		Convey("illegal input", func() {
+			So(SetDefaults(ServerConfig{}), ShouldNotBeNil)
+
+			type Bad struct {
+				Count int `valid:"default=abc"`
+			}
+			So(SetDefaults(&Bad{}), ShouldNotBeNil)
+		})
+	})
+}
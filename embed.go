@@ -0,0 +1,170 @@
+package qvalid
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// prefix of tag key to override valid tag of a promoted field,
+// e.g. `valid.id:"gt=0"` on an embedded struct field
+const overrideTagPrefix = validTag + "."
+
+type structField struct {
+	value reflect.Value
+	field reflect.StructField
+	depth int
+}
+
+// collect exported fields of struct, fields of embedded structs are promoted
+// like encoding/json does: a field with lower depth hides fields with the same name,
+// fields with the same name and depth hide each other unless only one has json tag
+func collectFields(val reflect.Value) []structField {
+	fields := collectFieldsDepth(val, 0, nil)
+
+	indexes := make(map[string][]int, len(fields))
+	for i, v := range fields {
+		name := getTagName(v.field)
+		indexes[name] = append(indexes[name], i)
+	}
+
+	result := make([]structField, 0, len(fields))
+	for i, v := range fields {
+		if dominantField(fields, indexes[getTagName(v.field)]) == i {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// index of dominant field among fields with the same name, -1 if they hide each other
+func dominantField(fields []structField, indexes []int) int {
+	depth := fields[indexes[0]].depth
+	for _, i := range indexes {
+		if fields[i].depth < depth {
+			depth = fields[i].depth
+		}
+	}
+
+	dominant, tagged := -1, -1
+	count, taggedCount := 0, 0
+	for _, i := range indexes {
+		if fields[i].depth != depth {
+			continue
+		}
+		dominant, count = i, count+1
+		if hasJsonName(fields[i].field) {
+			tagged, taggedCount = i, taggedCount+1
+		}
+	}
+	switch {
+	case count == 1:
+		return dominant
+	case taggedCount == 1:
+		return tagged
+	}
+	return -1
+}
+
+func collectFieldsDepth(val reflect.Value, depth int, overrides map[string]string) []structField {
+	fields := make([]structField, 0, val.NumField())
+	for i := 0; i < val.NumField(); i++ {
+		valueField := val.Field(i)
+		typeField := val.Type().Field(i)
+		if tag, ok := overrides[getTagName(typeField)]; ok {
+			typeField.Tag = overrideTag(typeField.Tag, tag)
+		}
+
+		// exported fields of unexported embedded struct are promoted as well
+		if isPromoted(valueField, typeField) {
+			if valueField.Kind() == reflect.Ptr {
+				valueField = valueField.Elem()
+			}
+			fields = append(fields, collectFieldsDepth(valueField, depth+1, getOverrides(typeField.Tag, overrides))...)
+			continue
+		}
+		if typeField.PkgPath != "" {
+			continue // Private field
+		}
+		fields = append(fields, structField{
+			value: valueField,
+			field: typeField,
+			depth: depth,
+		})
+	}
+	return fields
+}
+
+// embedded struct without json name is promoted
+func isPromoted(v reflect.Value, t reflect.StructField) bool {
+	if !t.Anonymous || hasJsonName(t) || t.Tag.Get(validTag) == "-" {
+		return false
+	}
+	if v.Kind() == reflect.Ptr {
+		return !v.IsNil() && v.Elem().Kind() == reflect.Struct
+	}
+	return v.Kind() == reflect.Struct
+}
+
+func hasJsonName(t reflect.StructField) bool {
+	return strings.Split(t.Tag.Get("json"), ",")[0] != ""
+}
+
+// merge override tags of an embedded field, outer overrides win
+func getOverrides(tag reflect.StructTag, outer map[string]string) map[string]string {
+	overrides := make(map[string]string, len(outer))
+	for _, key := range getTagKeys(tag) {
+		if strings.HasPrefix(key, overrideTagPrefix) {
+			overrides[strings.TrimPrefix(key, overrideTagPrefix)] = tag.Get(key)
+		}
+	}
+	for k, v := range outer {
+		overrides[k] = v
+	}
+	return overrides
+}
+
+// valid tag is looked up first, so put the override in front
+func overrideTag(tag reflect.StructTag, validTagValue string) reflect.StructTag {
+	return reflect.StructTag(validTag + ":" + strconv.Quote(validTagValue) + " " + string(tag))
+}
+
+// keys of tag in conventional format, see reflect.StructTag.Lookup
+func getTagKeys(tag reflect.StructTag) []string {
+	keys := make([]string, 0)
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := string(tag[:i])
+		tag = tag[i+1:]
+
+		// skip quoted value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		keys = append(keys, key)
+		tag = tag[i+1:]
+	}
+	return keys
+}
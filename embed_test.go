@@ -0,0 +1,112 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"reflect"
+	"testing"
+)
+
+type BaseModel struct {
+	ID      uint   `valid:"gte=1" json:"id"`
+	Creator string `valid:"gte=1" json:"creator"`
+}
+
+type Audit struct {
+	Remark string `valid:"lte=3" json:"remark"`
+}
+
+type timestamps struct {
+	CreatedAt int64 `valid:"gt=0" json:"created_at"`
+}
+
+type Article struct {
+	BaseModel `valid.creator:"-"`
+	*Audit
+	Meta    BaseModel `json:"meta"`
+	Title   string    `valid:"gte=1" json:"title"`
+	Creator string    `valid:"lte=5" json:"creator"` // hides BaseModel.Creator
+}
+
+func TestEmbeddedStruct(t *testing.T) {
+	Convey("TestEmbeddedStruct", t, func() {
+		Convey("tag keys", func() {
+			tag := reflect.StructTag(`json:"id" valid.name:"gt=1, lt=5" valid:"-"`)
+			So(getTagKeys(tag), ShouldResemble, []string{"json", "valid.name", "valid"})
+			So(overrideTag(tag, "lt=1").Get(validTag), ShouldEqual, "lt=1")
+		})
+
+		Convey("promoted fields", func() {
+			article := &Article{
+				Audit:   &Audit{Remark: "long remark"},
+				Meta:    BaseModel{ID: 1, Creator: "tom"},
+				Title:   "qvalid",
+				Creator: "tom and jerry",
+			}
+			isPass, validErrors := ValidateStruct(article)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 3)
+			So(validErrors[0].Field, ShouldEqual, ".id")
+			So(validErrors[1].Field, ShouldEqual, ".remark")
+			So(validErrors[2].Field, ShouldEqual, ".creator")
+		})
+
+		Convey("override promoted field tag", func() {
+			type Post struct {
+				BaseModel `valid.id:"lt=10"`
+			}
+			isPass, validErrors := ValidateStruct(&Post{BaseModel{ID: 10, Creator: "tom"}})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".id")
+
+			isPass, validErrors = ValidateStruct(&Post{BaseModel{Creator: "tom"}})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("embedded struct with json name is not promoted", func() {
+			type Post struct {
+				BaseModel `json:"base"`
+			}
+			isPass, validErrors := ValidateStruct(&Post{BaseModel{Creator: "tom"}})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".base.id")
+		})
+
+		Convey("field with lower depth hides conflicting promoted fields", func() {
+			type Author struct {
+				Name string `valid:"gte=1"`
+			}
+			type Editor struct {
+				Name string `valid:"gte=1"`
+			}
+			type Review struct {
+				Author
+				Editor
+				Name string `valid:"lte=2"`
+			}
+			isPass, validErrors := ValidateStruct(&Review{Name: "toolong"})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".Name")
+		})
+
+		Convey("exported fields of unexported embedded struct are promoted", func() {
+			type Comment struct {
+				timestamps
+				*Audit
+				Text string `valid:"gte=1" json:"text"`
+			}
+			isPass, validErrors := ValidateStruct(&Comment{Text: "ok", Audit: &Audit{Remark: "long"}})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Field, ShouldEqual, ".created_at")
+			So(validErrors[1].Field, ShouldEqual, ".remark")
+
+			isPass, validErrors = ValidateStruct(&Comment{timestamps: timestamps{CreatedAt: 1}, Audit: &Audit{}, Text: "ok"})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+	})
+}
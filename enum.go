@@ -0,0 +1,125 @@
+package qvalid
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// prefix of enum name in tag, e.g. `in=@currencies`
+const enumRefPrefix = "@"
+
+var (
+	enumMap   = make(map[string][]string)
+	enumMutex sync.RWMutex
+)
+
+// RegisterEnum registers values as a named enum, which can be used by tag `in=@name`.
+// register again replaces the values, tags referring to it see the new values at next check
+func RegisterEnum(name string, values ...string) {
+	enumMutex.Lock()
+	enumMap[name] = append([]string(nil), values...)
+	enumMutex.Unlock()
+}
+
+func getEnum(name string) ([]string, bool) {
+	enumMutex.RLock()
+	defer enumMutex.RUnlock()
+	values, ok := enumMap[name]
+	return values, ok
+}
+
+// CodeNotInEnum is the Code of ValidError when value is not one of the enum values
+const CodeNotInEnum = "not_in_enum"
+
+// EnumValidator is implemented by enum type to tell whether the value is one of its constants.
+// enum type may also have method `Values() []T` to list its constants, e.g.
+//
+//	type Status int
+//	func (s Status) Values() []Status { return []Status{StatusOn, StatusOff} }
+//
+// field with tag `enum` must be one of them. IsValid is preferred when both are implemented,
+// Values is used to report the allowed values
+type EnumValidator interface {
+	IsValid() bool
+}
+
+const enumValuesMethod = "Values"
+
+// get constants of enum type by method `Values() []T`, pointer receiver is found when v is addressable
+func enumValues(v reflect.Value) ([]interface{}, bool) {
+	method := v.MethodByName(enumValuesMethod)
+	if !method.IsValid() && v.CanAddr() {
+		method = v.Addr().MethodByName(enumValuesMethod)
+	}
+	if !method.IsValid() {
+		return nil, false
+	}
+	mt := method.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 {
+		return nil, false
+	}
+	if kind := mt.Out(0).Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return nil, false
+	}
+	list := method.Call(nil)[0]
+	values := make([]interface{}, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		values = append(values, list.Index(i).Interface())
+	}
+	return values, true
+}
+
+func enumValidator(v reflect.Value) (EnumValidator, bool) {
+	if validator, ok := v.Interface().(EnumValidator); ok {
+		return validator, true
+	}
+	if v.CanAddr() {
+		validator, ok := v.Addr().Interface().(EnumValidator)
+		return validator, ok
+	}
+	return nil, false
+}
+
+// check value of enum type by `enum`
+func (c *Constraint) checkEnum(path string, v reflect.Value, t reflect.StructField) (bool, *ValidError) {
+	if !c.Enum {
+		return true, nil
+	}
+	if !v.CanInterface() {
+		return true, nil
+	}
+
+	values, hasValues := enumValues(v)
+	validator, hasValidator := enumValidator(v)
+	isValid := false
+	switch {
+	case hasValidator:
+		isValid = validator.IsValid()
+	case hasValues:
+		for _, value := range values {
+			if reflect.DeepEqual(v.Interface(), value) {
+				isValid = true
+				break
+			}
+		}
+	default:
+		return false, &ValidError{
+			Field: path + getTagName(t),
+			Msg:   fmt.Sprintf("type:%s is not an enum", v.Type()),
+		}
+	}
+	if isValid {
+		return true, nil
+	}
+
+	validErr := &ValidError{
+		Field: path + getTagName(t),
+		Msg:   fmt.Sprintf("value:%v not in enum:%s", v.Interface(), v.Type()),
+		Code:  CodeNotInEnum,
+	}
+	if hasValues {
+		validErr.Msg = fmt.Sprintf("value:%v not in:%v", v.Interface(), values)
+	}
+	return false, validErr
+}
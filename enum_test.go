@@ -0,0 +1,80 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type Status int
+
+const (
+	StatusOn Status = iota + 1
+	StatusOff
+)
+
+func (s Status) Values() []Status {
+	return []Status{StatusOn, StatusOff}
+}
+
+type Level string
+
+func (l *Level) IsValid() bool {
+	return *l == "low" || *l == "high"
+}
+
+type Switch struct {
+	Status   Status  `valid:"enum" json:"status"`
+	Previous *Status `valid:"enum" json:"previous"`
+	Level    Level   `valid:"enum" json:"level"`
+}
+
+func TestEnum(t *testing.T) {
+	Convey("TestEnum", t, func() {
+		Convey("valid enum", func() {
+			previous := StatusOff
+			isPass, validErrors := ValidateStruct(&Switch{Status: StatusOn, Previous: &previous, Level: "low"})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("value not in Values", func() {
+			isPass, validErrors := ValidateStruct(&Switch{Status: 3, Level: "high"})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".status")
+			So(validErrors[0].Msg, ShouldEqual, "value:3 not in:[1 2]")
+			So(validErrors[0].Code, ShouldEqual, CodeNotInEnum)
+		})
+
+		Convey("IsValid with pointer receiver", func() {
+			isPass, validErrors := ValidateStruct(&Switch{Status: StatusOn, Level: "middle"})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".level")
+			So(validErrors[0].Msg, ShouldEqual, "value:middle not in enum:qvalid.Level")
+		})
+
+		Convey("not an enum type", func() {
+			type Bad struct {
+				Count int `valid:"enum"`
+			}
+			isPass, validErrors := ValidateStruct(&Bad{Count: 1})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Msg, ShouldEqual, "type:int is not an enum")
+		})
+
+		Convey("misspelled keyword", func() {
+			_, err := GetConstraintFromTag(`enmu`)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "keyword:enmu not supported")
+			_, err = GetConstraintFromTag(`uniqe, finit`)
+			So(err, ShouldNotBeNil)
+			_, err = GetConstraintFromTag(`lenght=3`)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "keyword:lenght not supported")
+			_, err = GetConstraintFromTag(`uniqe=.id`)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package qvalid
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func checkJSON(value string) error {
+	if !json.Valid([]byte(value)) {
+		return errors.New("not a JSON")
+	}
+	return nil
+}
+
+// header and payload of JWT are base64url encoded JSON objects, signature may be empty
+func checkJWT(value string) error {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return errors.New("JWT must have 3 parts")
+	}
+	for i, part := range parts[:2] {
+		data, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return fmt.Errorf("part %d of JWT is not base64url encoded", i+1)
+		}
+		var obj map[string]interface{}
+		if json.Unmarshal(data, &obj) != nil {
+			return fmt.Errorf("part %d of JWT is not a JSON object", i+1)
+		}
+		if _, ok := obj["alg"]; i == 0 && !ok {
+			return errors.New("header of JWT has no alg")
+		}
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return errors.New("signature of JWT is not base64url encoded")
+	}
+	return nil
+}
+
+// URL safe base64, padding is optional
+func checkBase64URL(value string) error {
+	if value == "" {
+		return errors.New("empty string")
+	}
+	if _, err := base64.URLEncoding.DecodeString(value); err == nil {
+		return nil
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(value); err != nil {
+		return errors.New("not a base64url string")
+	}
+	return nil
+}
+
+// standard base64 without padding
+func checkBase64Raw(value string) error {
+	if value == "" {
+		return errors.New("empty string")
+	}
+	if _, err := base64.RawStdEncoding.DecodeString(value); err != nil {
+		return errors.New("not a base64 string without padding")
+	}
+	return nil
+}
+
+var rgbRegex = regexp.MustCompile(`^rgb\(\s*(\d{1,3})(%?)\s*,\s*(\d{1,3})(%?)\s*,\s*(\d{1,3})(%?)\s*\)$`)
+
+// rgb(255, 0, 0) or rgb(100%, 0%, 0%)
+func checkRGB(value string) error {
+	matches := rgbRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return errors.New("not in format rgb(r, g, b)")
+	}
+	isPercent := matches[2] == "%"
+	for i := 1; i < len(matches); i += 2 {
+		if (matches[i+1] == "%") != isPercent {
+			return errors.New("can't mix number and percentage")
+		}
+		n, _ := strconv.Atoi(matches[i])
+		if (isPercent && n > 100) || n > 255 {
+			return fmt.Errorf("%s%s out of range", matches[i], matches[i+1])
+		}
+	}
+	return nil
+}
+
+func checkRFC3339(value string) error {
+	if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+		return errors.New("not a RFC 3339 time")
+	}
+	return nil
+}
+
+// named layouts of `datetime`, layout which contains ',' must be set by name
+var datetimeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+func getDatetimeLayout(layout string) string {
+	if v, ok := datetimeLayouts[layout]; ok {
+		return v
+	}
+	return layout
+}
@@ -0,0 +1,99 @@
+package qvalid
+
+import (
+	"context"
+	"strings"
+)
+
+// DefaultGroup is the group of rules without group prefix,
+// it is the only active group when no group is given
+const DefaultGroup = "default"
+
+const (
+	groupSeparator     = ";"
+	groupNameSeparator = "|"
+)
+
+type groupsKey struct{}
+
+// WithGroups returns a ctx which makes only rules of groups active
+func WithGroups(ctx context.Context, groups ...string) context.Context {
+	return context.WithValue(ctx, groupsKey{}, groups)
+}
+
+// ValidateStructGroups is like ValidateStruct, but only rules of groups are checked.
+// include DefaultGroup to check rules without group prefix as well
+func ValidateStructGroups(s interface{}, groups ...string) (bool, []*ValidError) {
+	return ValidateStructCtx(WithGroups(context.Background(), groups...), s)
+}
+
+func activeGroups(ctx context.Context) []string {
+	groups, _ := ctx.Value(groupsKey{}).([]string)
+	if len(groups) == 0 {
+		return []string{DefaultGroup}
+	}
+	return groups
+}
+
+// rules of a tag segment, e.g. `create|update: gt=0`
+type tagSegment struct {
+	groups []string
+	rule   string
+}
+
+// split tag into segments by ';', segment without group prefix belongs to DefaultGroup.
+// ';' in brackets or quotes doesn't split
+func splitTagSegments(tag string) ([]tagSegment, error) {
+	rules, err := splitTag(tag, groupSeparator[0])
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]tagSegment, 0)
+	for _, v := range rules {
+		segment := tagSegment{
+			groups: []string{DefaultGroup},
+			rule:   v,
+		}
+		// group prefix must be in front of any constraint
+		colon := strings.Index(v, ":")
+		if colon > 0 && !strings.ContainsAny(v[:colon], "=[],") {
+			segment.groups = strings.Split(v[:colon], groupNameSeparator)
+			for i := range segment.groups {
+				segment.groups[i] = strings.TrimSpace(segment.groups[i])
+			}
+			segment.rule = v[colon+1:]
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// get constraints of active groups from tag
+func getActiveConstraints(ctx context.Context, tag string) ([]*Constraint, error) {
+	groups := activeGroups(ctx)
+	constraints := make([]*Constraint, 0)
+	segments, err := splitTagSegments(tag)
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if !isGroupActive(segment.groups, groups) {
+			continue
+		}
+		constraint, err := getConstraint(segment.rule)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+func isGroupActive(segmentGroups, groups []string) bool {
+	for _, v := range segmentGroups {
+		if isInStringSlice(v, groups) {
+			return true
+		}
+	}
+	return false
+}
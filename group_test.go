@@ -0,0 +1,58 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type User struct {
+	ID   uint   `valid:"create: lt=1; update: gte=1" json:"id"`
+	Name string `valid:"gte=1, lte=10; create|update: attr=alpha" json:"name"`
+}
+
+func TestValidateStructGroups(t *testing.T) {
+	Convey("TestValidateStructGroups", t, func() {
+		Convey("split tag segments", func() {
+			segments, err := splitTagSegments("gt=1; create|update: in=[a,b]")
+			So(err, ShouldBeNil)
+			So(len(segments), ShouldEqual, 2)
+			So(segments[0].groups, ShouldResemble, []string{DefaultGroup})
+			So(segments[1].groups, ShouldResemble, []string{"create", "update"})
+			So(segments[1].rule, ShouldEqual, " in=[a,b]")
+
+			segments, err = splitTagSegments("in=['a;b', c]; update: gt=0")
+			So(err, ShouldBeNil)
+			So(len(segments), ShouldEqual, 2)
+			So(segments[0].rule, ShouldEqual, "in=['a;b', c]")
+		})
+
+		Convey("default group only", func() {
+			isPass, validErrors := ValidateStruct(&User{ID: 10, Name: "a1"})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("create group", func() {
+			isPass, validErrors := ValidateStructGroups(&User{Name: "tom"}, "create")
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+
+			isPass, validErrors = ValidateStructGroups(&User{ID: 1, Name: "tom"}, "create")
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".id")
+		})
+
+		Convey("update group with default", func() {
+			isPass, validErrors := ValidateStructGroups(&User{Name: "tom1"}, "update", DefaultGroup)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Field, ShouldEqual, ".id")
+			So(validErrors[1].Field, ShouldEqual, ".name")
+
+			isPass, validErrors = ValidateStructGroups(&User{ID: 1, Name: "tom"}, "update", DefaultGroup)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+	})
+}
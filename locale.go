@@ -0,0 +1,112 @@
+package qvalid
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CountryFormat checks value by regex of country, country is set by tag like `attr=postcode:CN|HK`
+type CountryFormat map[string]*regexp.Regexp
+
+// Check matches value with any of the countries in args
+func (f CountryFormat) Check(value string, args []string) error {
+	if len(args) == 0 {
+		return errors.New("country not set")
+	}
+	for _, country := range args {
+		regex, ok := f[strings.ToUpper(country)]
+		if !ok {
+			return fmt.Errorf("country:%s not supported", country)
+		}
+		if regex.MatchString(value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("not in format of country:%s", strings.Join(args, "|"))
+}
+
+// MobileFormats are mobile phone numbers of countries, with optional country calling code
+var MobileFormats = CountryFormat{
+	"CN": regexp.MustCompile(`^(?:\+?86)?1[3-9]\d{9}$`),
+	"HK": regexp.MustCompile(`^(?:\+?852)?[4-9]\d{7}$`),
+	"TW": regexp.MustCompile(`^(?:\+?886|0)9\d{8}$`),
+	"JP": regexp.MustCompile(`^(?:\+?81|0)[789]0\d{8}$`),
+	"KR": regexp.MustCompile(`^(?:\+?82|0)1[0-9]\d{7,8}$`),
+	"US": regexp.MustCompile(`^(?:\+?1)?[2-9]\d{2}[2-9]\d{6}$`),
+	"CA": regexp.MustCompile(`^(?:\+?1)?[2-9]\d{2}[2-9]\d{6}$`),
+	"GB": regexp.MustCompile(`^(?:\+?44|0)7\d{9}$`),
+	"DE": regexp.MustCompile(`^(?:\+?49|0)1[5-7]\d{8,9}$`),
+	"FR": regexp.MustCompile(`^(?:\+?33|0)[67]\d{8}$`),
+	"ES": regexp.MustCompile(`^(?:\+?34)?[67]\d{8}$`),
+	"IT": regexp.MustCompile(`^(?:\+?39)?3\d{8,9}$`),
+	"NL": regexp.MustCompile(`^(?:\+?31|0)6\d{8}$`),
+}
+
+// PostcodeFormats are postal codes of countries
+var PostcodeFormats = CountryFormat{
+	"CN": regexp.MustCompile(`^[0-8]\d{5}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+	"KR": regexp.MustCompile(`^\d{5}$`),
+	"US": regexp.MustCompile(`^\d{5}(?:-\d{4})?$`),
+	"CA": regexp.MustCompile(`^[ABCEGHJ-NPRSTVXY]\d[ABCEGHJ-NPRSTV-Z] ?\d[ABCEGHJ-NPRSTV-Z]\d$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^(?:0[1-9]|[1-4]\d|5[0-2])\d{3}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^[1-9]\d{3} ?[A-Z]{2}$`),
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^\d{4}$`),
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+}
+
+var e164Regex = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// LocalePack is registered by default
+var LocalePack = FormatPack{
+	StringTypeE164:     withoutArgs(checkE164),
+	StringTypeMobile:   MobileFormats.Check,
+	StringTypePostcode: PostcodeFormats.Check,
+	StringTypeCNID:     withoutArgs(checkCNID),
+}
+
+func init() {
+	RegisterFormatPack(LocalePack)
+}
+
+func checkE164(value string) error {
+	if !e164Regex.MatchString(value) {
+		return errors.New("not an E.164 phone number")
+	}
+	return nil
+}
+
+var (
+	cnIDRegex   = regexp.MustCompile(`^[1-9]\d{16}[\dXx]$`)
+	cnIDWeights = []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	cnIDChecks  = "10X98765432"
+)
+
+// 18 digits chinese resident ID, birth date and check digit are checked
+func checkCNID(value string) error {
+	if !cnIDRegex.MatchString(value) {
+		return errors.New("not a chinese resident ID")
+	}
+	if _, err := time.Parse("20060102", value[6:14]); err != nil {
+		return fmt.Errorf("birth date:%s is illegal", value[6:14])
+	}
+	sum := 0
+	for i, w := range cnIDWeights {
+		sum += int(value[i]-'0') * w
+	}
+	if cnIDChecks[sum%11] != strings.ToUpper(value[17:])[0] {
+		return errors.New("check digit is wrong")
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package qvalid
+
+import (
+	"context"
+	"reflect"
+)
+
+// check each key of map by constraints of tag validkey, e.g. `validkey:"attr=hostname, lte=63"`,
+// error field of a key is like `.labels[key]`
+func checkMapKeys(ctx context.Context, path string, v reflect.Value, t reflect.StructField) (bool, []*ValidError) {
+	tag := t.Tag.Get(validKeyTag)
+	if tag == "" || tag == "-" {
+		return true, nil
+	}
+	constraints, err := getActiveConstraints(ctx, tag)
+	if err != nil {
+		return false, []*ValidError{{
+			Field: systemTips + " GetConstraintFromTag",
+			Msg:   err.Error(),
+		}}
+	}
+
+	result := true
+	validErrors := make([]*ValidError, 0)
+	for _, elem := range getCollectionElems(v) {
+		if isDone(ctx, &validErrors) {
+			return false, validErrors
+		}
+		key := indirectValue(elem.value)
+		if !key.IsValid() {
+			continue
+		}
+		keyField := reflect.StructField{Name: getTagName(t) + elem.name}
+		isPass, validErrs := checkConstraints(ctx, constraints, path, key, keyField)
+		for _, validErr := range validErrs {
+			validErr.IsKey = true
+		}
+		validErrors = append(validErrors, validErrs...)
+		result = result && isPass
+	}
+	return result, validErrors
+}
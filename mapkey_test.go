@@ -0,0 +1,56 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type Pod struct {
+	Labels map[string]string `valid:"lte=3" validkey:"attr=dns_label, lte=10" json:"labels"`
+	Ports  map[int]string    `validkey:"gt=0, lt=65536; strict: lt=10000"`
+}
+
+func TestMapKey(t *testing.T) {
+	Convey("TestMapKey", t, func() {
+		Convey("valid keys", func() {
+			isPass, validErrors := ValidateStruct(&Pod{
+				Labels: map[string]string{"app": "web", "tier": "Front"},
+				Ports:  map[int]string{80: "http", 8080: "admin"},
+			})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("invalid keys", func() {
+			isPass, validErrors := ValidateStruct(&Pod{
+				Labels: map[string]string{"App": "web", "tier": "Front", "very-long-key": ""},
+				Ports:  map[int]string{0: "", 80: "http"},
+			})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 3)
+			So(validErrors[0].Field, ShouldEqual, ".labels[App]")
+			So(validErrors[0].IsKey, ShouldBeTrue)
+			So(validErrors[1].Field, ShouldEqual, ".labels[very-long-key]")
+			So(validErrors[1].Msg, ShouldEqual, "expect length <= 10 but get length: 13")
+			So(validErrors[2].Field, ShouldEqual, ".Ports[0]")
+			So(validErrors[2].IsKey, ShouldBeTrue)
+		})
+
+		Convey("value of map is checked as well", func() {
+			isPass, validErrors := ValidateStruct(&Pod{
+				Labels: map[string]string{"a": "", "b": "", "c": "", "d": ""},
+			})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".labels")
+			So(validErrors[0].IsKey, ShouldBeFalse)
+		})
+
+		Convey("key constraints of groups", func() {
+			isPass, validErrors := ValidateStructGroups(&Pod{Ports: map[int]string{20000: ""}}, DefaultGroup, "strict")
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".Ports[20000]")
+		})
+	})
+}
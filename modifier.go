@@ -0,0 +1,117 @@
+package qvalid
+
+import (
+	"context"
+	"golang.org/x/text/unicode/norm"
+	"reflect"
+	"strings"
+)
+
+type transformOnlyKey struct{}
+
+// WithTransformOnly returns a ctx which makes ValidateStructCtx only apply modifiers like trim and lower,
+// constraints, customized validators and struct hooks are skipped
+func WithTransformOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transformOnlyKey{}, true)
+}
+
+// TransformStruct applies modifiers to string fields of s in place without validation,
+// string elements of slices and arrays and string values of maps are modified as well,
+// s must be a pointer to struct. errors are only of illegal tags
+func TransformStruct(s interface{}) (bool, []*ValidError) {
+	return ValidateStructCtx(WithTransformOnly(context.Background()), s)
+}
+
+func isTransformOnly(ctx context.Context) bool {
+	isTransformOnly, _ := ctx.Value(transformOnlyKey{}).(bool)
+	return isTransformOnly
+}
+
+func (c *Constraint) hasModifier() bool {
+	return c.Trim || c.Lower || c.Upper || c.NFC
+}
+
+// modify s by trim, nfc, then lower or upper
+func (c *Constraint) modify(s string) string {
+	if c.Trim {
+		s = strings.TrimSpace(s)
+	}
+	if c.NFC {
+		s = norm.NFC.String(s)
+	}
+	if c.Lower {
+		s = strings.ToLower(s)
+	}
+	if c.Upper {
+		s = strings.ToUpper(s)
+	}
+	return s
+}
+
+// apply modifiers of constraints to string, string elements of slice and array, and string values of map.
+// the value is changed in place if it can be set, otherwise a modified copy is returned
+func applyModifiers(constraints []*Constraint, v reflect.Value) reflect.Value {
+	modify := func(s string) string {
+		for _, c := range constraints {
+			if c.hasModifier() {
+				s = c.modify(s)
+			}
+		}
+		return s
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if s := modify(v.String()); s != v.String() {
+			if !v.CanSet() {
+				v = copyValue(v)
+			}
+			v.SetString(s)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		for i := 0; i < v.Len(); i++ {
+			if s := modify(v.Index(i).String()); s != v.Index(i).String() {
+				if !v.CanSet() {
+					v = copyValue(v)
+				}
+				v.Index(i).SetString(s)
+			}
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			break
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if s := modify(iter.Value().String()); s != iter.Value().String() {
+				if !v.CanSet() {
+					v = copyValue(v)
+				}
+				v.SetMapIndex(iter.Key(), reflect.ValueOf(s).Convert(v.Type().Elem()))
+			}
+		}
+	}
+	return v
+}
+
+// settable copy of v, elements of slice and map are copied as well
+func copyValue(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Slice:
+		c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		reflect.Copy(c, v)
+	case reflect.Map:
+		c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		c.Set(v)
+	}
+	return c
+}
@@ -0,0 +1,88 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type Account struct {
+	Email    string            `valid:"trim, lower, attr=email" json:"email"`
+	Country  string            `valid:"trim, upper, in=[CN,US]" json:"country"`
+	Name     *string           `valid:"nfc, trim, gte=1" json:"name"`
+	Password string            `valid:"gte=8" json:"password"`
+	Aliases  []string          `valid:"trim, lower, unique" json:"aliases"`
+	Labels   map[string]string `valid:"trim" json:"labels"`
+}
+
+func TestModifier(t *testing.T) {
+	Convey("TestModifier", t, func() {
+		Convey("modify in place by pointer", func() {
+			name := " Café "
+			account := &Account{Email: " Tom@Example.COM ", Country: "cn ", Name: &name, Password: "12345678"}
+			isPass, validErrors := ValidateStruct(account)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+			So(account.Email, ShouldEqual, "tom@example.com")
+			So(account.Country, ShouldEqual, "CN")
+			So(*account.Name, ShouldEqual, "Café")
+		})
+
+		Convey("modify string elements and map values", func() {
+			name := "tom"
+			account := &Account{Email: "tom@example.com", Country: "CN", Name: &name, Password: "12345678",
+				Aliases: []string{" Tom ", "jerry"}, Labels: map[string]string{"team": " go "}}
+			isPass, validErrors := ValidateStruct(account)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+			So(account.Aliases, ShouldResemble, []string{"tom", "jerry"})
+			So(account.Labels["team"], ShouldEqual, "go")
+
+			// modified copy is checked when struct is given by value
+			aliases, labels := []string{"Tom", " tom"}, map[string]string{"team": " go "}
+			isPass, validErrors = ValidateStruct(Account{Email: "tom@example.com", Country: "CN", Name: &name, Password: "12345678",
+				Aliases: aliases, Labels: labels})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Msg, ShouldEqual, "duplicate elements at:[1]")
+			So(aliases, ShouldResemble, []string{"Tom", " tom"})
+			So(labels["team"], ShouldEqual, " go ")
+		})
+
+		Convey("check modified copy by value", func() {
+			name := "tom"
+			account := Account{Email: " Tom@Example.COM ", Country: "us", Name: &name, Password: "12345678"}
+			isPass, validErrors := ValidateStruct(account)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+			So(account.Email, ShouldEqual, " Tom@Example.COM ")
+		})
+
+		Convey("transform only", func() {
+			name := " "
+			account := &Account{Email: " A@B ", Country: " jp", Name: &name, Password: "1"}
+			isPass, validErrors := TransformStruct(account)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+			So(account.Email, ShouldEqual, "a@b")
+			So(account.Country, ShouldEqual, "JP")
+			So(*account.Name, ShouldEqual, "")
+
+			isPass, validErrors = ValidateStruct(account)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 4)
+		})
+
+		Convey("transform only passes nil pointer", func() {
+			account := &Account{Email: " A@B "}
+			isPass, validErrors := TransformStruct(account)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+			So(account.Email, ShouldEqual, "a@b")
+		})
+
+		Convey("lower and upper can't both set", func() {
+			_, err := GetConstraintFromTag(`lower, upper`)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,259 @@
+package qvalid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+// exact value of big.Int, big.Float or big.Rat, inf is the sign of infinite big.Float
+type bigNumber struct {
+	rat *big.Rat
+	inf int
+}
+
+// value of int, uint, float and big number, isNaN is true if float is NaN
+func getNumber(v reflect.Value) (n bigNumber, isNaN bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return bigNumber{rat: new(big.Rat).SetInt64(v.Int())}, false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return bigNumber{rat: new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint()))}, false
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			return bigNumber{}, true
+		case math.IsInf(f, 0):
+			return bigNumber{inf: int(math.Copysign(1, f))}, false
+		}
+		return bigNumber{rat: floatToRatBits(f, v.Type().Bits())}, false
+	}
+	return getBigNumber(v), false
+}
+
+func (n bigNumber) String() string {
+	switch {
+	case n.inf > 0:
+		return "+Inf"
+	case n.inf < 0:
+		return "-Inf"
+	case n.rat.IsInt():
+		return n.rat.Num().String()
+	}
+	// decimal if it is finite, e.g. 1/8 is 0.125 but 1/3 keeps as it is
+	if digits, ok := decimalPlaces(n.rat); ok {
+		return n.rat.FloatString(digits)
+	}
+	return n.rat.String()
+}
+
+// count of decimal places of r, ok is false if r is a repeating decimal like 1/3
+func decimalPlaces(r *big.Rat) (digits int, ok bool) {
+	// finite decimal has only factor 2 and 5 in denominator
+	denom := new(big.Int).Set(r.Denom())
+	for _, p := range []int64{2, 5} {
+		count := 0
+		for m := new(big.Int); ; count++ {
+			if m.Mod(denom, big.NewInt(p)).Sign() != 0 {
+				break
+			}
+			denom.Div(denom, big.NewInt(p))
+		}
+		if count > digits {
+			digits = count
+		}
+	}
+	return digits, denom.Cmp(big.NewInt(1)) == 0
+}
+
+func (n bigNumber) cmp(o bigNumber) int {
+	if n.inf != 0 || o.inf != 0 {
+		switch {
+		case n.inf < o.inf:
+			return -1
+		case n.inf > o.inf:
+			return 1
+		}
+		return 0
+	}
+	return n.rat.Cmp(o.rat)
+}
+
+func isBigNumber(v reflect.Value) bool {
+	t := v.Type()
+	return t == bigIntType || t == bigFloatType || t == bigRatType
+}
+
+func getBigNumber(v reflect.Value) bigNumber {
+	switch x := addrOf(v).Interface().(type) {
+	case *big.Int:
+		return bigNumber{rat: new(big.Rat).SetInt(x)}
+	case *big.Float:
+		if x.IsInf() {
+			return bigNumber{inf: x.Sign()}
+		}
+		rat, _ := x.Rat(nil)
+		return bigNumber{rat: rat}
+	case *big.Rat:
+		return bigNumber{rat: x}
+	}
+	return bigNumber{rat: new(big.Rat)}
+}
+
+// keys of bound limit
+const (
+	limitLt  = "lt"
+	limitLte = "lte"
+	limitGt  = "gt"
+	limitGte = "gte"
+)
+
+var limitKeys = []string{limitLt, limitLte, limitGt, limitGte}
+
+// limit in tag as exact number, e.g. 0.1 is 1/10 rather than the nearest float64
+func floatToRat(f float64) *big.Rat {
+	return floatToRatBits(f, 64)
+}
+
+// shortest decimal of float which reads back to it, float32 0.1 is 1/10 as well
+func floatToRatBits(f float64, bitSize int) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, bitSize))
+	return r
+}
+
+// exact limit of bound, parsed from tag text
+func (c *Constraint) getBigLimit(key string) (bigNumber, bool) {
+	if limit, ok := c.bigLimits[key]; ok {
+		return bigNumber{rat: limit}, true
+	}
+	limit := map[string]*float64{limitLt: c.Lt, limitLte: c.Lte, limitGt: c.Gt, limitGte: c.Gte}[key]
+	if limit == nil || math.IsNaN(*limit) {
+		return bigNumber{}, false
+	}
+	if math.IsInf(*limit, 0) {
+		return bigNumber{inf: int(math.Copysign(1, *limit))}, true
+	}
+	return bigNumber{rat: floatToRat(*limit)}, true
+}
+
+func (c *Constraint) getBigLowBoundLimit() (bigNumber, bool) {
+	if c.Gt != nil {
+		return c.getBigLimit(limitGt)
+	}
+	return c.getBigLimit(limitGte)
+}
+
+func (c *Constraint) getBigUpperBoundLimit() (bigNumber, bool) {
+	if c.Lt != nil {
+		return c.getBigLimit(limitLt)
+	}
+	return c.getBigLimit(limitLte)
+}
+
+// check bound of big number exactly
+func (c *Constraint) checkBigBoundLimit(n bigNumber) error {
+	for _, bound := range []struct {
+		key  string
+		op   string
+		pass func(r int) bool
+	}{
+		{limitGt, ">", func(r int) bool { return r > 0 }},
+		{limitGte, ">=", func(r int) bool { return r >= 0 }},
+		{limitLt, "<", func(r int) bool { return r < 0 }},
+		{limitLte, "<=", func(r int) bool { return r <= 0 }},
+	} {
+		limit, ok := c.getBigLimit(bound.key)
+		if !ok {
+			continue
+		}
+		if !bound.pass(n.cmp(limit)) {
+			return fmt.Errorf("expect value %s %v but get value:%v", bound.op, limit, n)
+		}
+	}
+	return nil
+}
+
+// big number is in `in` if it equals to any item
+func (c *Constraint) isBigIn(n bigNumber) bool {
+	for _, v := range c.inValues() {
+		if r, ok := new(big.Rat).SetString(strings.TrimSpace(v)); ok && n.cmp(bigNumber{rat: r}) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Constraint) hasNumberConstraint() bool {
+	return c.multipleOf != nil || c.MaxDecimals != nil || c.Finite || c.Positive || c.Negative || c.NonZero
+}
+
+// check multiple_of, max_decimals, finite, positive, negative and nonzero
+func (c *Constraint) checkNumber(v reflect.Value) error {
+	if !c.hasNumberConstraint() {
+		return nil
+	}
+	n, isNaN := getNumber(v)
+	switch {
+	case isNaN && c.Finite:
+		return errors.New("value:NaN is not finite")
+	case isNaN:
+		return errors.New("value:NaN is not comparable")
+	case n.inf != 0 && (c.Finite || c.multipleOf != nil || c.MaxDecimals != nil):
+		return fmt.Errorf("value:%v is not finite", n)
+	}
+
+	sign := n.inf
+	if n.inf == 0 {
+		sign = n.rat.Sign()
+	}
+	switch {
+	case c.Positive && sign <= 0:
+		return fmt.Errorf("value:%v is not positive", n)
+	case c.Negative && sign >= 0:
+		return fmt.Errorf("value:%v is not negative", n)
+	case c.NonZero && sign == 0:
+		return fmt.Errorf("value:%v is zero", n)
+	}
+
+	if c.multipleOf != nil && !new(big.Rat).Quo(n.rat, c.multipleOf).IsInt() {
+		return fmt.Errorf("value:%v is not multiple of %s", n, *c.MultipleOf)
+	}
+	if c.MaxDecimals != nil {
+		if digits, ok := decimalPlaces(n.rat); !ok || digits > *c.MaxDecimals {
+			return fmt.Errorf("value:%v has more than %d decimal places", n, *c.MaxDecimals)
+		}
+	}
+	return nil
+}
+
+func (c *Constraint) checkBigNumber(path string, v reflect.Value, t reflect.StructField) (bool, *ValidError) {
+	n := getBigNumber(v)
+	err := c.checkBigBoundLimit(n)
+	if err == nil {
+		err = c.checkNumber(v)
+	}
+	if err != nil {
+		return false, &ValidError{
+			Field: path + getTagName(t),
+			Msg:   err.Error(),
+		}
+	}
+	if c.hasIn() && !c.isBigIn(n) {
+		return false, &ValidError{
+			Field: path + getTagName(t),
+			Msg:   fmt.Sprintf("value:%v not in:%s", n, c.inDesc()),
+		}
+	}
+	return true, nil
+}
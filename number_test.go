@@ -0,0 +1,129 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestBigNumberLimit(t *testing.T) {
+	Convey("TestBigNumberLimit", t, func() {
+		field := reflect.StructField{Name: "Amount"}
+		bigInt := func(s string) reflect.Value {
+			n, _ := new(big.Int).SetString(s, 10)
+			return reflect.ValueOf(n).Elem()
+		}
+
+		Convey("limit out of float64 precision", func() {
+			c, err := GetConstraintFromTag(`gt=18446744073709551615, lt=18446744073709551617`)
+			So(err, ShouldBeNil)
+			isPass, validErr := c.checkValue(".", bigInt("18446744073709551616"), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldBeTrue)
+
+			isPass, validErr = c.checkValue(".", bigInt("18446744073709551615"), field)
+			So(isPass, ShouldBeFalse)
+			So(validErr.Msg, ShouldEqual, "expect value > 18446744073709551615 but get value:18446744073709551615")
+		})
+
+		Convey("limit out of float64 range", func() {
+			c, err := GetConstraintFromTag(`lte=1e400`)
+			So(err, ShouldBeNil)
+			isPass, validErr := c.checkValue(".", reflect.ValueOf(1e308), reflect.StructField{Name: "Float"})
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldBeTrue)
+
+			n := new(big.Int).Exp(big.NewInt(10), big.NewInt(400), nil)
+			isPass, validErr = c.checkValue(".", reflect.ValueOf(n).Elem(), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldBeTrue)
+
+			isPass, validErr = c.checkValue(".", reflect.ValueOf(n.Add(n, big.NewInt(1))).Elem(), field)
+			So(validErr, ShouldNotBeNil)
+			So(isPass, ShouldBeFalse)
+		})
+
+		Convey("decimal limit is exact", func() {
+			c, err := GetConstraintFromTag(`gte=0.1`)
+			So(err, ShouldBeNil)
+			isPass, validErr := c.checkValue(".", reflect.ValueOf(big.NewRat(1, 10)).Elem(), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldBeTrue)
+
+			f, _ := new(big.Float).SetPrec(200).SetString("0.09999999999999999999")
+			isPass, validErr = c.checkValue(".", reflect.ValueOf(f).Elem(), field)
+			So(isPass, ShouldBeFalse)
+			So(validErr.Msg, ShouldStartWith, "expect value >= 0.1 but get value:0.0999999999999999999")
+		})
+
+		Convey("bound order is checked exactly", func() {
+			_, err := GetConstraintFromTag(`gt=18446744073709551616, lt=18446744073709551617`)
+			So(err, ShouldBeNil)
+			_, err = GetConstraintFromTag(`gt=18446744073709551617, lt=18446744073709551616`)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+type Payment struct {
+	Amount   float64 `valid:"gt=0, multiple_of=0.01" json:"amount"`
+	Percent  float32 `valid:"max_decimals=2, finite" json:"percent"`
+	Quantity uint    `valid:"nonzero" json:"quantity"`
+	Discount int     `valid:"negative" json:"discount"`
+	Fee      big.Rat `valid:"positive, multiple_of=1/3" json:"fee"`
+}
+
+func TestNumberConstraint(t *testing.T) {
+	Convey("TestNumberConstraint", t, func() {
+		newPayment := func() *Payment {
+			p := &Payment{Amount: 10.01, Percent: 12.34, Quantity: 1, Discount: -5}
+			p.Fee.SetFrac64(2, 3)
+			return p
+		}
+
+		Convey("valid numbers", func() {
+			isPass, validErrors := ValidateStruct(newPayment())
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("invalid numbers", func() {
+			p := newPayment()
+			p.Amount = 0.015
+			p.Percent = 1.234
+			p.Quantity = 0
+			p.Discount = 0
+			p.Fee.SetFrac64(-1, 3)
+			isPass, validErrors := ValidateStruct(p)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 5)
+			So(validErrors[0].Msg, ShouldEqual, "value:0.015 is not multiple of 0.01")
+			So(validErrors[1].Msg, ShouldEqual, "value:1.234 has more than 2 decimal places")
+			So(validErrors[2].Msg, ShouldEqual, "value:0 is zero")
+			So(validErrors[3].Msg, ShouldEqual, "value:0 is not negative")
+			So(validErrors[4].Msg, ShouldEqual, "value:-1/3 is not positive")
+		})
+
+		Convey("NaN and Inf", func() {
+			p := newPayment()
+			p.Amount = math.NaN()
+			p.Percent = float32(math.Inf(1))
+			isPass, validErrors := ValidateStruct(p)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Msg, ShouldEqual, "value:NaN is not comparable with bound")
+			So(validErrors[1].Msg, ShouldEqual, "value:+Inf is not finite")
+		})
+
+		Convey("illegal settings", func() {
+			_, err := GetConstraintFromTag(`multiple_of=0`)
+			So(err, ShouldNotBeNil)
+			_, err = GetConstraintFromTag(`max_decimals=-1`)
+			So(err, ShouldNotBeNil)
+			_, err = GetConstraintFromTag(`positive, negative`)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package qvalid
+
+import (
+	"context"
+	"strings"
+)
+
+type pathFilterKey struct{}
+
+// filter fields by paths like `.Leafs[0].name`, `.Leafs[*].name` or `.Leafs.name`
+type pathFilter struct {
+	paths   [][]string
+	exclude bool
+}
+
+// relation of a field path to a filter path
+const (
+	pathUnrelated = iota
+	pathAncestor  // filter path is under the field
+	pathMatched   // field is the filter path or under it
+)
+
+// WithPartial returns a ctx which makes only fields of paths be validated
+func WithPartial(ctx context.Context, paths ...string) context.Context {
+	return context.WithValue(ctx, pathFilterKey{}, newPathFilter(paths, false))
+}
+
+// WithExcept returns a ctx which makes fields of paths be skipped
+func WithExcept(ctx context.Context, paths ...string) context.Context {
+	return context.WithValue(ctx, pathFilterKey{}, newPathFilter(paths, true))
+}
+
+// ValidatePartial only validates fields of paths and the fields under them,
+// path is the same as Field of ValidError, index of slice can be `[*]` or omitted to match all elements
+func ValidatePartial(s interface{}, paths ...string) (bool, []*ValidError) {
+	return ValidateStructCtx(WithPartial(context.Background(), paths...), s)
+}
+
+// ValidateExcept validates all fields except fields of paths and the fields under them
+func ValidateExcept(s interface{}, paths ...string) (bool, []*ValidError) {
+	return ValidateStructCtx(WithExcept(context.Background(), paths...), s)
+}
+
+func newPathFilter(paths []string, exclude bool) *pathFilter {
+	f := &pathFilter{
+		exclude: exclude,
+	}
+	for _, v := range paths {
+		f.paths = append(f.paths, splitPath(v))
+	}
+	return f
+}
+
+// visitPath reports whether constraints of the field at path should be checked,
+// and whether fields or elements under it should be visited
+func visitPath(ctx context.Context, path string) (isCheck bool, isDescend bool) {
+	f, _ := ctx.Value(pathFilterKey{}).(*pathFilter)
+	if f == nil {
+		return true, true
+	}
+
+	fieldPath := splitPath(path)
+	for _, v := range f.paths {
+		switch matchPath(v, fieldPath) {
+		case pathMatched:
+			if f.exclude {
+				return false, false
+			}
+			return true, true
+		case pathAncestor:
+			isDescend = true
+		}
+	}
+	if f.exclude {
+		return true, true
+	}
+	return false, isDescend
+}
+
+// split `.Leafs[0].name` into `Leafs`, `[0]`, `name`
+func splitPath(path string) []string {
+	segments := make([]string, 0)
+	for _, v := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		if v == "" {
+			continue
+		}
+		if i := strings.Index(v, "["); i >= 0 {
+			if i > 0 {
+				segments = append(segments, v[:i])
+			}
+			segments = append(segments, v[i:])
+			continue
+		}
+		segments = append(segments, v)
+	}
+	return segments
+}
+
+func isIndexSegment(segment string) bool {
+	return strings.HasPrefix(segment, "[")
+}
+
+func matchPath(filterPath, fieldPath []string) int {
+	i, j := 0, 0
+	for i < len(filterPath) && j < len(fieldPath) {
+		switch {
+		case isIndexSegment(filterPath[i]) && isIndexSegment(fieldPath[j]):
+			if filterPath[i] != "[*]" && filterPath[i] != fieldPath[j] {
+				return pathUnrelated
+			}
+		case isIndexSegment(fieldPath[j]):
+			// index omitted in filter path, match all elements
+			j++
+			continue
+		case filterPath[i] != fieldPath[j]:
+			return pathUnrelated
+		}
+		i++
+		j++
+	}
+	if i < len(filterPath) {
+		return pathAncestor
+	}
+	return pathMatched
+}
@@ -0,0 +1,65 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+type Garden struct {
+	Name   string  `valid:"gte=1" json:"name"`
+	Owner  Owner   `json:"owner"`
+	Flower []Leaf  `valid:"gte=2" json:"flowers"`
+	Area   float64 `valid:"gt=0" json:"area"`
+}
+
+type Owner struct {
+	Name  string `valid:"gte=1" json:"name"`
+	Email string `valid:"attr=email" json:"email"`
+}
+
+type Leaf struct {
+	Name  string `valid:"in=[rose,tulip]" json:"name"`
+	Color string `valid:"gte=1" json:"color"`
+}
+
+func TestValidatePartial(t *testing.T) {
+	Convey("TestValidatePartial", t, func() {
+		garden := &Garden{
+			Flower: []Leaf{{Name: "rose"}, {Name: "grass", Color: "green"}},
+		}
+
+		Convey("match path", func() {
+			So(matchPath(splitPath(".flowers.name"), splitPath(".flowers[1].name")), ShouldEqual, pathMatched)
+			So(matchPath(splitPath(".flowers[*].name"), splitPath(".flowers[1]")), ShouldEqual, pathAncestor)
+			So(matchPath(splitPath(".flowers[0].name"), splitPath(".flowers[1].name")), ShouldEqual, pathUnrelated)
+			So(matchPath(splitPath(".owner"), splitPath(".owner.email")), ShouldEqual, pathMatched)
+		})
+
+		Convey("partial", func() {
+			isPass, validErrors := ValidatePartial(garden, ".name", ".owner.email")
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Field, ShouldEqual, ".name")
+			So(validErrors[1].Field, ShouldEqual, ".owner.email")
+
+			// length of flowers is not checked
+			isPass, validErrors = ValidatePartial(garden, ".flowers[*].name")
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".flowers[1].name")
+
+			isPass, validErrors = ValidatePartial(garden, "flowers[0]")
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".flowers[0].color")
+		})
+
+		Convey("except", func() {
+			isPass, validErrors := ValidateExcept(garden, ".owner", ".flowers.color", ".area")
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Field, ShouldEqual, ".name")
+			So(validErrors[1].Field, ShouldEqual, ".flowers[1].name")
+		})
+	})
+}
@@ -0,0 +1,305 @@
+package qvalid
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semantic version, build metadata is ignored
+type semver struct {
+	major, minor, patch uint64
+	pre                 []string
+}
+
+func (v semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if len(v.pre) > 0 {
+		s += "-" + strings.Join(v.pre, ".")
+	}
+	return s
+}
+
+var semverRegex = regexp.MustCompile(Semver)
+
+// version in range, the '*', 'x' or missing part is counted by parts
+var partialSemverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*|[xX*])(?:\.(0|[1-9]\d*|[xX*]))?(?:\.(0|[1-9]\d*|[xX*]))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+func parseSemver(s string) (semver, error) {
+	if !semverRegex.MatchString(s) {
+		return semver{}, fmt.Errorf("%s is not a semantic version", s)
+	}
+	v, _, err := parsePartialSemver(s)
+	return v, err
+}
+
+// parse version like `1`, `1.2`, `1.2.x`, parts is the number of given numeric parts
+func parsePartialSemver(s string) (v semver, parts int, err error) {
+	matches := partialSemverRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return v, 0, fmt.Errorf("%s is not a semantic version", s)
+	}
+	numbers := []*uint64{&v.major, &v.minor, &v.patch}
+	for i, n := range numbers {
+		text := matches[i+1]
+		if text == "" || text == "x" || text == "X" || text == "*" {
+			break
+		}
+		if *n, err = strconv.ParseUint(text, 10, 64); err != nil {
+			return v, 0, fmt.Errorf("%s is not a semantic version", s)
+		}
+		parts++
+	}
+	if matches[4] != "" {
+		v.pre = strings.Split(matches[4], ".")
+	}
+	return v, parts, nil
+}
+
+// compare by precedence of semantic version
+func compareSemver(a, b semver) int {
+	for _, v := range [][2]uint64{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if v[0] != v[1] {
+			if v[0] < v[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// version without pre-release has higher precedence
+	switch {
+	case len(a.pre) == 0 && len(b.pre) == 0:
+		return 0
+	case len(a.pre) == 0:
+		return 1
+	case len(b.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		if c := comparePreRelease(a.pre[i], b.pre[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a.pre) < len(b.pre):
+		return -1
+	case len(a.pre) > len(b.pre):
+		return 1
+	}
+	return 0
+}
+
+// numeric identifier is compared numerically and is lower than alphanumeric one
+func comparePreRelease(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+type semverComparator struct {
+	op string
+	v  semver
+}
+
+func (c semverComparator) match(v semver) bool {
+	r := compareSemver(v, c.v)
+	switch c.op {
+	case "<":
+		return r < 0
+	case "<=":
+		return r <= 0
+	case ">":
+		return r > 0
+	case ">=":
+		return r >= 0
+	}
+	return r == 0
+}
+
+// comparators joined by space must all match, sets joined by `||` match any
+type semverRange [][]semverComparator
+
+func (r semverRange) match(v semver) bool {
+	for _, set := range r {
+		isMatch := true
+		for _, c := range set {
+			isMatch = isMatch && c.match(v)
+		}
+		if isMatch {
+			return true
+		}
+	}
+	return false
+}
+
+func (r semverRange) String() string {
+	sets := make([]string, 0, len(r))
+	for _, set := range r {
+		comparators := make([]string, 0, len(set))
+		for _, c := range set {
+			comparators = append(comparators, c.op+c.v.String())
+		}
+		sets = append(sets, strings.Join(comparators, " "))
+	}
+	return strings.Join(sets, " || ")
+}
+
+func (r semverRange) and(set []semverComparator) semverRange {
+	if len(r) == 0 {
+		return semverRange{set}
+	}
+	for i := range r {
+		r[i] = append(r[i], set...)
+	}
+	return r
+}
+
+// parse range like `>=1.2 <2.0 || ^3.1`, supports <, <=, >, >=, =, ~ and ^
+func parseSemverRange(s string) (semverRange, error) {
+	r := semverRange{}
+	for _, setText := range strings.Split(s, "||") {
+		set := make([]semverComparator, 0)
+		for _, text := range strings.Fields(setText) {
+			comparators, err := parseSemverComparator(text)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, comparators...)
+		}
+		if len(set) == 0 {
+			return nil, fmt.Errorf("semver range:%s has empty set", s)
+		}
+		r = append(r, set)
+	}
+	return r, nil
+}
+
+func parseSemverComparator(text string) ([]semverComparator, error) {
+	op := ""
+	for _, v := range []string{"<=", ">=", "<", ">", "=", "~", "^"} {
+		if strings.HasPrefix(text, v) {
+			op = v
+			break
+		}
+	}
+	v, parts, err := parsePartialSemver(strings.TrimPrefix(text, op))
+	if err != nil {
+		return nil, err
+	}
+	if parts == 0 {
+		// `*` matches any version
+		return []semverComparator{{">=", semver{}}}, nil
+	}
+
+	switch op {
+	case "<", ">=":
+		return []semverComparator{{op, v}}, nil
+	case ">":
+		if parts < 3 {
+			// >1.2 means >=1.3.0
+			return []semverComparator{{">=", bumpSemver(v, parts)}}, nil
+		}
+		return []semverComparator{{op, v}}, nil
+	case "<=":
+		if parts < 3 {
+			// <=1.2 means <1.3.0
+			return []semverComparator{{"<", bumpSemver(v, parts)}}, nil
+		}
+		return []semverComparator{{op, v}}, nil
+	case "~":
+		// ~1.2.3 means >=1.2.3 <1.3.0, ~1 means >=1.0.0 <2.0.0
+		if parts > 2 {
+			parts = 2
+		}
+		return []semverComparator{{">=", v}, {"<", bumpSemver(v, parts)}}, nil
+	case "^":
+		// bump the left-most non-zero part
+		upper := 1
+		switch {
+		case v.major == 0 && v.minor == 0 && parts == 3:
+			upper = 3
+		case v.major == 0 && parts >= 2:
+			upper = 2
+		}
+		return []semverComparator{{">=", v}, {"<", bumpSemver(v, upper)}}, nil
+	}
+
+	// `=` or no operator, partial version means a range
+	if parts == 3 {
+		return []semverComparator{{"=", v}}, nil
+	}
+	return []semverComparator{{">=", v}, {"<", bumpSemver(v, parts)}}, nil
+}
+
+// increase the part at position parts, lower parts are set to 0
+func bumpSemver(v semver, parts int) semver {
+	switch parts {
+	case 1:
+		return semver{major: v.major + 1}
+	case 2:
+		return semver{major: v.major, minor: v.minor + 1}
+	}
+	return semver{major: v.major, minor: v.minor, patch: v.patch + 1}
+}
+
+// range made of semver_* bounds and semver_range, nil if none is set
+func (c *Constraint) getSemverRange() (semverRange, error) {
+	var r semverRange
+	if c.SemverRange != nil {
+		var err error
+		if r, err = parseSemverRange(*c.SemverRange); err != nil {
+			return nil, err
+		}
+	}
+
+	set := make([]semverComparator, 0)
+	for _, bound := range []struct {
+		op    string
+		value *string
+	}{{"<", c.SemverLt}, {"<=", c.SemverLte}, {">", c.SemverGt}, {">=", c.SemverGte}} {
+		if bound.value == nil {
+			continue
+		}
+		v, err := parseSemver(*bound.value)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, semverComparator{bound.op, v})
+	}
+	if len(set) > 0 {
+		r = r.and(set)
+	}
+	return r, nil
+}
+
+func (c *Constraint) checkSemver(value string) error {
+	r := c.semverRange
+	if r == nil {
+		return nil
+	}
+	v, err := parseSemver(value)
+	if err != nil {
+		return errors.New("is not a semantic version")
+	}
+	if !r.match(v) {
+		return fmt.Errorf("not in semver range:%s", r)
+	}
+	return nil
+}
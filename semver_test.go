@@ -0,0 +1,92 @@
+package qvalid
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"reflect"
+	"testing"
+)
+
+func TestSemver(t *testing.T) {
+	Convey("TestSemver", t, func() {
+		Convey("precedence", func() {
+			versions := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.10.0", "2.0.0"}
+			for i := 0; i < len(versions)-1; i++ {
+				a, err := parseSemver(versions[i])
+				So(err, ShouldBeNil)
+				b, err := parseSemver(versions[i+1])
+				So(err, ShouldBeNil)
+				So(compareSemver(a, b), ShouldEqual, -1)
+				So(compareSemver(b, a), ShouldEqual, 1)
+			}
+			a, _ := parseSemver("v1.0.0+build.1")
+			b, _ := parseSemver("1.0.0+build.2")
+			So(compareSemver(a, b), ShouldEqual, 0)
+		})
+
+		Convey("range", func() {
+			cases := []struct {
+				rangeText string
+				valid     []string
+				bad       []string
+			}{
+				{">=1.2 <2.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0", "1.2.0-rc.1"}},
+				{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+				{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"2.0.0", "1.2.2"}},
+				{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+				{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+				{"1.2.x || >=3.0.0-beta", []string{"1.2.0", "1.2.99", "3.0.0-beta", "4.0.0"}, []string{"1.3.0", "3.0.0-alpha"}},
+				{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+				{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+				{"*", []string{"0.0.0", "9.9.9"}, nil},
+			}
+			for _, v := range cases {
+				r, err := parseSemverRange(v.rangeText)
+				So(err, ShouldBeNil)
+				for _, version := range v.valid {
+					sv, err := parseSemver(version)
+					So(err, ShouldBeNil)
+					So(r.match(sv), ShouldBeTrue)
+				}
+				for _, version := range v.bad {
+					sv, err := parseSemver(version)
+					So(err, ShouldBeNil)
+					So(r.match(sv), ShouldBeFalse)
+				}
+			}
+
+			_, err := parseSemverRange(">=1.2 ||")
+			So(err, ShouldNotBeNil)
+			_, err = parseSemverRange(">=a.b")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("constraint", func() {
+			field := reflect.StructField{Name: "Version"}
+			c, err := GetConstraintFromTag(`semver_gte=1.4.0, semver_range='<2.0 || >=3.0.0'`)
+			So(err, ShouldBeNil)
+
+			isPass, validErr := c.checkValue(".", reflect.ValueOf("1.10.0"), field)
+			So(validErr, ShouldBeNil)
+			So(isPass, ShouldBeTrue)
+
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("1.4.0-rc.1"), field)
+			So(validErr, ShouldNotBeNil)
+			So(isPass, ShouldBeFalse)
+
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("2.1.0"), field)
+			So(validErr, ShouldNotBeNil)
+			So(validErr.Msg, ShouldEqual, "value:2.1.0 not in semver range:<2.0.0 >=1.4.0 || >=3.0.0 >=1.4.0")
+
+			isPass, validErr = c.checkValue(".", reflect.ValueOf("1.4"), field)
+			So(validErr, ShouldNotBeNil)
+			So(isPass, ShouldBeFalse)
+
+			_, err = GetConstraintFromTag(`semver_lt=1.x`)
+			So(err, ShouldNotBeNil)
+			_, err = GetConstraintFromTag(`semver_range=>=1.2`)
+			So(err, ShouldNotBeNil)
+			_, err = GetConstraintFromTag(`lt=abc`)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package qvalid
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -8,15 +9,24 @@ import (
 
 // result will be equal to `false` if there are any errors.
 func ValidateStruct(s interface{}) (bool, []*ValidError) {
-	return validateStruct("", s)
+	return ValidateStructCtx(context.Background(), s)
+}
+
+// ValidateStructCtx is like ValidateStruct, ctx is passed to customized validators and
+// struct hooks, and validation stops once ctx is done.
+func ValidateStructCtx(ctx context.Context, s interface{}) (bool, []*ValidError) {
+	return validateStruct(ctx, "", s)
 }
 
 const systemTips = "[qvalid]"
 
-func validateStruct(path string, s interface{}) (bool, []*ValidError) {
+func validateStruct(ctx context.Context, path string, s interface{}) (bool, []*ValidError) {
 	if s == nil {
 		return true, nil
 	}
+	if err := ctx.Err(); err != nil {
+		return false, []*ValidError{newContextError(err)}
+	}
 	result := true
 	newPath := path + "."
 	validErrors := make([]*ValidError, 0)
@@ -34,18 +44,20 @@ func validateStruct(path string, s interface{}) (bool, []*ValidError) {
 		return false, validErrors
 	}
 
-	for i := 0; i < val.NumField(); i++ {
-		valueField := val.Field(i)
-		typeField := val.Type().Field(i)
-		if typeField.PkgPath != "" {
-			continue // Private field
+	// fields of embedded structs are promoted
+	for _, field := range collectFields(val) {
+		if isDone(ctx, &validErrors) {
+			return false, validErrors
 		}
-		if valueField.Kind() == reflect.Interface {
-			valueField = valueField.Elem()
+		valueField := field.value
+		typeField := field.field
+		if isCheck, isDescend := visitPath(ctx, newPath+getTagName(typeField)); !isCheck && !isDescend {
+			continue // filtered out
 		}
+		// interface field is checked by its dynamic value in typeCheck, wrapper and big number field by its value
 		if (valueField.Kind() == reflect.Struct || (valueField.Kind() == reflect.Ptr && valueField.Elem().Kind() == reflect.Struct)) &&
-			typeField.Tag.Get(validTag) != "-" {
-			isTypeValid, validErrs := validateStruct(newPath+getTagName(typeField), valueField.Interface())
+			typeField.Tag.Get(validTag) != "-" && !isValueStruct(valueField) {
+			isTypeValid, validErrs := validateStruct(ctx, newPath+getTagName(typeField), addrInterface(valueField))
 			if len(validErrs) > 0 {
 				validErrors = append(validErrors, validErrs...)
 			}
@@ -57,19 +69,31 @@ func validateStruct(path string, s interface{}) (bool, []*ValidError) {
 			valueField = valueField.Elem()
 		}
 
-		isTypeValid, validErrs := typeCheck(newPath, valueField, typeField)
+		isTypeValid, validErrs := typeCheck(ctx, newPath, valueField, typeField)
 		if len(validErrs) > 0 {
 			validErrors = append(validErrors, validErrs...)
 		}
 		result = result && isTypeValid
 	}
+	if isDone(ctx, &validErrors) {
+		return false, validErrors
+	}
+
+	// struct hook runs after all fields, skip it when fields are partially selected
+	if isCheck, _ := visitPath(ctx, path); isCheck && !isTransformOnly(ctx) {
+		if validErr := callStructValidator(ctx, path, val); validErr != nil {
+			validErrors = append(validErrors, validErr)
+			result = false
+		}
+	}
 	return result, validErrors
 }
 
 // don't check invalid value
-func typeCheck(path string, v reflect.Value, t reflect.StructField) (isValid bool, validErrors []*ValidError) {
+func typeCheck(ctx context.Context, path string, v reflect.Value, t reflect.StructField) (isValid bool, validErrors []*ValidError) {
 	if !v.IsValid() {
-		return false, nil
+		// nothing to modify, e.g. nil pointer
+		return isTransformOnly(ctx), nil
 	}
 
 	validErrors = make([]*ValidError, 0)
@@ -81,7 +105,13 @@ func typeCheck(path string, v reflect.Value, t reflect.StructField) (isValid boo
 		return true, nil
 	}
 
-	constraint, err := GetConstraintFromTag(tag)
+	// wrapper is checked by its underlying value, invalid one is treated as nil
+	v, ok := unwrapValue(v)
+	if !ok {
+		return true, nil
+	}
+
+	constraints, err := getActiveConstraints(ctx, tag)
 	if err != nil {
 		validErrors = append(validErrors, &ValidError{
 			Field: systemTips + " GetConstraintFromTag",
@@ -92,42 +122,57 @@ func typeCheck(path string, v reflect.Value, t reflect.StructField) (isValid boo
 
 	//TODO check loop
 
+	// constraints of field are skipped when only fields under it are selected
+	isCheck, _ := visitPath(ctx, path+getTagName(t))
+	if !isCheck {
+		constraints = nil
+	}
+
+	// modifiers change the value before it is checked
+	v = applyModifiers(constraints, v)
+	if isTransformOnly(ctx) {
+		constraints, isCheck = nil, false
+	}
+
 	switch v.Kind() {
 	case reflect.Bool,
 		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
 		reflect.Float32, reflect.Float64,
 		reflect.String:
-		isPass, validErr := constraint.checkValue(path, v, t)
-		if validErr != nil {
-			validErrors = append(validErrors, validErr)
-		}
-		isValid = isPass
-		return
+		return checkConstraints(ctx, constraints, path, v, t)
 
 	case reflect.Map:
 		// map只检查元素数量，因为key的类型不确定，value的元素也不确定
-		isPass, validErr := constraint.checkValue(path, v, t)
-		if validErr != nil {
-			validErrors = append(validErrors, validErr)
+		result, validErrs := checkConstraints(ctx, constraints, path, v, t)
+		validErrors = append(validErrors, validErrs...)
+		if isCheck {
+			// keys are checked by tag validkey
+			isPass, validErrs := checkMapKeys(ctx, path, v, t)
+			validErrors = append(validErrors, validErrs...)
+			result = result && isPass
 		}
-		isValid = isPass
-		return
+		return result, validErrors
 
 	case reflect.Slice, reflect.Array:
 		// only trace when slice element is struct
-		result := true
-
-		isPass, validErr := constraint.checkValue(path, v, t)
-		if validErr != nil {
-			validErrors = append(validErrors, validErr)
-		}
-
-		result = result && isPass
+		result, validErrs := checkConstraints(ctx, constraints, path, v, t)
+		validErrors = append(validErrors, validErrs...)
 
 		for i := 0; i < v.Len(); i++ {
-			if v.Index(i).Kind() == reflect.Struct || (v.Index(i).Kind() == reflect.Ptr && v.Index(i).Elem().Kind() == reflect.Struct) {
-				isPass, validErrs := validateStruct(path+fmt.Sprintf("%s[%d]", getTagName(t), i), v.Index(i).Interface())
+			if isDone(ctx, &validErrors) {
+				return false, validErrors
+			}
+			elemPath := path + fmt.Sprintf("%s[%d]", getTagName(t), i)
+			if isCheck, isDescend := visitPath(ctx, elemPath); !isCheck && !isDescend {
+				continue
+			}
+			elem := v.Index(i)
+			if elem.Kind() == reflect.Interface {
+				elem = elem.Elem()
+			}
+			if (elem.Kind() == reflect.Struct || (elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct)) && !isValueStruct(elem) {
+				isPass, validErrs := validateStruct(ctx, elemPath, addrInterface(elem))
 				if len(validErrs) > 0 {
 					validErrors = append(validErrors, validErrs...)
 				}
@@ -137,19 +182,27 @@ func typeCheck(path string, v reflect.Value, t reflect.StructField) (isValid boo
 		isValid = result
 		return
 	case reflect.Interface:
-		// If the value is an interface then encode its element
+		// If the value is an interface then check its dynamic value
 		if v.IsNil() {
 			return true, nil
 		}
-		return ValidateStruct(v.Interface())
+		for _, constraint := range constraints {
+			if validErr := constraint.checkType(path, v.Elem(), t); validErr != nil {
+				return false, []*ValidError{validErr}
+			}
+		}
+		return typeCheck(ctx, path, v.Elem(), t)
 	case reflect.Ptr:
 		// If the value is a pointer then check its element
 		if v.IsNil() {
 			return true, nil
 		}
-		return typeCheck(path, v.Elem(), t)
+		return typeCheck(ctx, path, v.Elem(), t)
 	case reflect.Struct:
-		return validateStruct("", v.Interface())
+		if isBigNumber(v) {
+			return checkConstraints(ctx, constraints, path, v, t)
+		}
+		return validateStruct(ctx, path+getTagName(t), addrInterface(v))
 	default:
 		validErrors = append(validErrors, &ValidError{
 			Msg: "unsupported type",
@@ -158,6 +211,34 @@ func typeCheck(path string, v reflect.Value, t reflect.StructField) (isValid boo
 	}
 }
 
+// check v with every constraint, each constraint stops at its first failure
+func checkConstraints(ctx context.Context, constraints []*Constraint, path string, v reflect.Value, t reflect.StructField) (bool, []*ValidError) {
+	result := true
+	validErrors := make([]*ValidError, 0)
+	for _, constraint := range constraints {
+		isPass, validErr := constraint.checkValue(path, v, t)
+		if isPass {
+			isPass, validErr = constraint.checkEnum(path, v, t)
+		}
+		if isPass {
+			isPass, validErr = constraint.checkFunc(ctx, path, v, t)
+		}
+		if validErr != nil {
+			validErrors = append(validErrors, validErr)
+		}
+		result = result && isPass
+	}
+	return result, validErrors
+}
+
+// pass struct by address when possible, so pointer receiver hooks are found
+func addrInterface(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Struct && v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	return v.Interface()
+}
+
 // json tag first
 func getTagName(t reflect.StructField) string {
 	jsonTagStr := t.Tag.Get("json")
@@ -170,7 +251,60 @@ func getTagName(t reflect.StructField) string {
 	return t.Name
 }
 
+// StructValidator can be implemented by a struct to do cross field checks,
+// it is called after all fields of the struct have been validated
+type StructValidator interface {
+	Validate(ctx context.Context) error
+}
+
+func callStructValidator(ctx context.Context, path string, val reflect.Value) *ValidError {
+	var sv StructValidator
+	if val.CanAddr() {
+		sv, _ = val.Addr().Interface().(StructValidator)
+	}
+	if sv == nil && val.CanInterface() {
+		sv, _ = val.Interface().(StructValidator)
+	}
+	if sv == nil {
+		return nil
+	}
+	if err := sv.Validate(ctx); err != nil {
+		if path == "" {
+			path = "."
+		}
+		return &ValidError{
+			Field: path,
+			Msg:   err.Error(),
+		}
+	}
+	return nil
+}
+
+func newContextError(err error) *ValidError {
+	return &ValidError{
+		Field: systemTips + " context",
+		Msg:   err.Error(),
+	}
+}
+
+// isDone reports whether ctx is done, the reason is appended to validErrors only once
+func isDone(ctx context.Context, validErrors *[]*ValidError) bool {
+	err := ctx.Err()
+	if err == nil {
+		return false
+	}
+	for _, v := range *validErrors {
+		if v.Field == systemTips+" context" {
+			return true
+		}
+	}
+	*validErrors = append(*validErrors, newContextError(err))
+	return true
+}
+
 type ValidError struct {
 	Field string
 	Msg   string
+	Code  string // set when the reason can be told by code, see AttrError
+	IsKey bool   // set when a map key rather than its value fails, see tag validkey
 }
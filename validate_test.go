@@ -0,0 +1,118 @@
+package qvalid
+
+import (
+	"context"
+	"errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"reflect"
+	"testing"
+)
+
+type tenantKey struct{}
+
+type Order struct {
+	Code  string  `valid:"func=tenant_code" json:"code"`
+	Items []Item  `valid:"gte=1" json:"items"`
+	Total float64 `json:"total"`
+}
+
+type Item struct {
+	Price float64 `valid:"gt=0" json:"price"`
+}
+
+func (o *Order) Validate(ctx context.Context) error {
+	sum := 0.0
+	for _, v := range o.Items {
+		sum += v.Price
+	}
+	if sum != o.Total {
+		return errors.New("total not equal to sum of items")
+	}
+	return nil
+}
+
+func init() {
+	RegisterValidator("tenant_code", func(ctx context.Context, v reflect.Value) error {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		if v.String() != tenant+"-order" {
+			return errors.New("code not belong to tenant " + tenant)
+		}
+		return nil
+	})
+}
+
+func TestValidateStructCtx(t *testing.T) {
+	Convey("TestValidateStructCtx", t, func() {
+		ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+		Convey("custom validator and struct hook get ctx", func() {
+			order := &Order{Code: "acme-order", Items: []Item{{Price: 1}, {Price: 2}}, Total: 3}
+			isPass, validErrors := ValidateStructCtx(ctx, order)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+
+			order.Code = "other-order"
+			order.Total = 4
+			isPass, validErrors = ValidateStructCtx(ctx, order)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Field, ShouldEqual, ".code")
+			So(validErrors[1].Field, ShouldEqual, ".")
+		})
+
+		Convey("unregistered validator", func() {
+			type Bad struct {
+				Name string `valid:"func=not_exist"`
+			}
+			isPass, validErrors := ValidateStruct(&Bad{})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+		})
+
+		Convey("cancelled ctx stops validation", func() {
+			cancelCtx, cancel := context.WithCancel(ctx)
+			cancel()
+			order := &Order{Code: "x", Items: make([]Item, 100)}
+			isPass, validErrors := ValidateStructCtx(cancelCtx, order)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Msg, ShouldEqual, context.Canceled.Error())
+		})
+	})
+}
+
+func TestValidateInterfaceField(t *testing.T) {
+	Convey("TestValidateInterfaceField", t, func() {
+		type Event struct {
+			Payload interface{}   `valid:"type=string|number, lt=5" json:"payload"`
+			Extra   interface{}   `json:"extra"`
+			Items   []interface{} `json:"items"`
+		}
+
+		Convey("tag applies to dynamic value", func() {
+			isPass, validErrors := ValidateStruct(&Event{Payload: "abc"})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+
+			isPass, validErrors = ValidateStruct(&Event{Payload: 10})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Field, ShouldEqual, ".payload")
+		})
+
+		Convey("type assertion", func() {
+			isPass, validErrors := ValidateStruct(&Event{Payload: true})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 1)
+			So(validErrors[0].Msg, ShouldEqual, "expect type:string|number but get type:bool")
+		})
+
+		Convey("nested struct keeps path", func() {
+			isPass, validErrors := ValidateStruct(&Event{Extra: &Item{}, Items: []interface{}{1, Item{Price: 1}, Item{}}})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 2)
+			So(validErrors[0].Field, ShouldEqual, ".extra.price")
+			So(validErrors[1].Field, ShouldEqual, ".items[2].price")
+		})
+	})
+}
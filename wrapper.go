@@ -0,0 +1,122 @@
+package qvalid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// unwrap value of wrapper type, ok is false when the wrapped value is invalid, e.g. sql.NullString with Valid false
+type unwrapFunc func(v reflect.Value) (value interface{}, ok bool)
+
+var (
+	wrapperMu  sync.RWMutex
+	wrapperMap = map[reflect.Type]unwrapFunc{}
+)
+
+func init() {
+	RegisterWrapperType(
+		sql.NullString{}, sql.NullInt64{}, sql.NullInt32{}, sql.NullInt16{}, sql.NullByte{},
+		sql.NullFloat64{}, sql.NullBool{}, sql.NullTime{},
+	)
+}
+
+// RegisterWrapperType registers types whose field is validated by its underlying value instead of its fields.
+// the type must implement driver.Valuer, encoding.TextMarshaler or fmt.Stringer, and is unwrapped by the first one,
+// nil returned by driver.Valuer means invalid and the field is treated as nil
+func RegisterWrapperType(types ...interface{}) {
+	wrapperMu.Lock()
+	defer wrapperMu.Unlock()
+	for _, v := range types {
+		t := reflect.TypeOf(v)
+		fn := getUnwrapFunc(t)
+		if fn == nil {
+			panic(fmt.Sprintf("qvalid: type:%s is not a wrapper", t))
+		}
+		wrapperMap[t] = fn
+	}
+}
+
+// TypeFunc extracts a validatable value from value of registered type, e.g. *big.Rat of a decimal type,
+// which is checked instead. return nil when the value is invalid, and the field is treated as nil
+type TypeFunc func(v reflect.Value) interface{}
+
+// RegisterTypeFunc registers fn to extract value of the type of typ, typ is any value of the type.
+// return *big.Int, *big.Float or *big.Rat to check bounds and `in` exactly
+func RegisterTypeFunc(typ interface{}, fn TypeFunc) {
+	wrapperMu.Lock()
+	defer wrapperMu.Unlock()
+	wrapperMap[reflect.TypeOf(typ)] = func(v reflect.Value) (interface{}, bool) {
+		value := fn(v)
+		return value, value != nil
+	}
+}
+
+func getUnwrapFunc(t reflect.Type) unwrapFunc {
+	valuerType := reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	textMarshalerType := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	switch {
+	case t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType):
+		return func(v reflect.Value) (interface{}, bool) {
+			value, err := addrOf(v).Interface().(driver.Valuer).Value()
+			return value, err == nil && value != nil
+		}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return func(v reflect.Value) (interface{}, bool) {
+			text, err := addrOf(v).Interface().(encoding.TextMarshaler).MarshalText()
+			return string(text), err == nil
+		}
+	case t.Implements(stringerType) || reflect.PtrTo(t).Implements(stringerType):
+		return func(v reflect.Value) (interface{}, bool) {
+			return addrOf(v).Interface().(fmt.Stringer).String(), true
+		}
+	}
+	return nil
+}
+
+// pointer to v, so methods of both value and pointer receiver are available
+func addrOf(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+// struct checked by its value rather than its fields, i.e. wrapper type and big number
+func isValueStruct(v reflect.Value) bool {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	if isBigNumber(v) {
+		return true
+	}
+	wrapperMu.RLock()
+	defer wrapperMu.RUnlock()
+	_, ok := wrapperMap[v.Type()]
+	return ok
+}
+
+// underlying value of wrapper type, ok is false when it is invalid.
+// value of other type is returned as it is
+func unwrapValue(v reflect.Value) (reflect.Value, bool) {
+	wrapperMu.RLock()
+	fn, isWrapper := wrapperMap[v.Type()]
+	wrapperMu.RUnlock()
+	if !isWrapper || !v.CanInterface() {
+		return v, true
+	}
+	value, ok := fn(v)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(value), true
+}
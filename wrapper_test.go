@@ -0,0 +1,134 @@
+package qvalid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// wrapper like null.String
+type NullName struct {
+	Name  string
+	Valid bool
+}
+
+func (n NullName) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Name, nil
+}
+
+type Version struct {
+	Major, Minor int
+}
+
+func (v *Version) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%d", v.Major, v.Minor)), nil
+}
+
+// decimal type like decimal.Decimal
+type Decimal struct {
+	text string
+}
+
+func init() {
+	RegisterWrapperType(NullName{}, Version{})
+	RegisterTypeFunc(Decimal{}, func(v reflect.Value) interface{} {
+		r, ok := new(big.Rat).SetString(v.Interface().(Decimal).text)
+		if !ok {
+			return nil
+		}
+		return r
+	})
+}
+
+type Ledger struct {
+	Amount  Decimal  `valid:"gt=0, lt=1" json:"amount"`
+	Balance *big.Int `valid:"gte=0" json:"balance"`
+	Rate    big.Rat  `valid:"in=[0.5, 0.25]" json:"rate"`
+}
+
+type Profile struct {
+	Nick     sql.NullString   `valid:"lt=10" json:"nick"`
+	Age      *sql.NullInt64   `valid:"gte=18" json:"age"`
+	Name     NullName         `valid:"attr=alpha" json:"name"`
+	Version  Version          `valid:"in=['1.0', '1.1']" json:"version"`
+	Aliases  []sql.NullString `valid:"lte=2" json:"aliases"`
+	Nickname sql.NullString   `valid:"-" json:"nickname"`
+}
+
+func TestWrapperType(t *testing.T) {
+	Convey("TestWrapperType", t, func() {
+		Convey("valid wrapper values", func() {
+			isPass, validErrors := ValidateStruct(&Profile{
+				Nick:    sql.NullString{String: "tom", Valid: true},
+				Age:     &sql.NullInt64{Int64: 20, Valid: true},
+				Name:    NullName{Name: "tom", Valid: true},
+				Version: Version{1, 1},
+			})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("invalid wrapper is treated as nil", func() {
+			isPass, validErrors := ValidateStruct(&Profile{
+				Nick:    sql.NullString{String: "a very long nick", Valid: false},
+				Age:     &sql.NullInt64{Int64: 1, Valid: false},
+				Name:    NullName{Name: "123"},
+				Version: Version{1, 0},
+			})
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+
+		Convey("underlying values are checked", func() {
+			isPass, validErrors := ValidateStruct(&Profile{
+				Nick:    sql.NullString{String: "a very long nick", Valid: true},
+				Age:     &sql.NullInt64{Int64: 1, Valid: true},
+				Name:    NullName{Name: "123", Valid: true},
+				Version: Version{2, 0},
+			})
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 4)
+			So(validErrors[0].Field, ShouldEqual, ".nick")
+			So(validErrors[1].Field, ShouldEqual, ".age")
+			So(validErrors[2].Field, ShouldEqual, ".name")
+			So(validErrors[3].Field, ShouldEqual, ".version")
+		})
+
+		Convey("type without unwrap method can't be registered", func() {
+			So(func() { RegisterWrapperType(Leaf{}) }, ShouldPanic)
+		})
+
+		Convey("type func keeps precision", func() {
+			ledger := &Ledger{Amount: Decimal{"0.99999999999999999999"}, Balance: big.NewInt(1)}
+			ledger.Rate.SetFrac64(1, 4)
+			isPass, validErrors := ValidateStruct(ledger)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+
+			ledger.Amount = Decimal{"1.00000000000000000001"}
+			ledger.Balance, _ = new(big.Int).SetString("-100000000000000000000000", 10)
+			ledger.Rate.SetFrac64(1, 3)
+			isPass, validErrors = ValidateStruct(ledger)
+			So(isPass, ShouldBeFalse)
+			So(len(validErrors), ShouldEqual, 3)
+			So(validErrors[0].Msg, ShouldEqual, "expect value < 1 but get value:1.00000000000000000001")
+			So(validErrors[1].Msg, ShouldEqual, "expect value >= 0 but get value:-100000000000000000000000")
+			So(validErrors[2].Msg, ShouldEqual, "value:1/3 not in:[0.5 0.25]")
+		})
+
+		Convey("invalid value of type func is treated as nil", func() {
+			ledger := &Ledger{Amount: Decimal{"n/a"}, Balance: big.NewInt(0)}
+			ledger.Rate.SetFrac64(1, 2)
+			isPass, validErrors := ValidateStruct(ledger)
+			So(validErrors, ShouldBeEmpty)
+			So(isPass, ShouldBeTrue)
+		})
+	})
+}